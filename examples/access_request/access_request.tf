@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_access_request" "web_to_db" {
+  subject      = "App web tier to database"
+  sources      = ["10.10.0.0/24"]
+  destinations = ["db-prod-01", "10.20.0.15"]
+  services     = ["tcp 5432"]
+  comment      = "Requested by Terraform workspace ${terraform.workspace}"
+}
@@ -0,0 +1,12 @@
+# Checks the flow against the Unified Security Policy at plan time, without opening a ticket.
+# Critical and high violations fail the plan, lower ones are shown as warnings.
+data "tufin_risk_analysis" "web_to_db" {
+  sources            = ["10.10.0.0/24"]
+  destinations       = ["db-prod-01", "10.20.0.15"]
+  services           = ["tcp 5432"]
+  severity_threshold = "high"
+}
+
+output "web_to_db_violations" {
+  value = data.tufin_risk_analysis.web_to_db.violations[*].description
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_host_object" "app_subnet" {
+  name    = "N_app-prod"
+  address = "10.50.0.0/24"
+  comment = "Managed by Terraform workspace ${terraform.workspace}"
+  devices = ["fw-core-01", "10.0.0.2"]
+}
+
+# A pre-approved standard change: the provider's service account completes the
+# approval and verification steps itself instead of waiting for a human.
+resource "tufin_host_object" "monitoring_probe" {
+  name               = "H_probe-01"
+  address            = "10.50.1.10"
+  devices            = ["fw-core-01"]
+  justification      = "Standard change SC-042: monitoring probes"
+  auto_advance_steps = ["Approve", "Verify"]
+}
@@ -20,10 +20,13 @@ provider "tufin" {
   user = "example"
   password = "example"
   allow_insecure = true
+
+  # Open one ticket per group and device rather than one per member
+  batch_group_changes = true
 }
 
 resource "tufin_group_member" "multiples" {
   count = length(local.firewall_groups)
-  local.firewall_groups[count.index]
+  group_name = local.firewall_groups[count.index]
   ip_address = "1.1.1.1"
 }
@@ -0,0 +1,84 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+data "tufin_rules" "core_accept" {
+  device       = "fw-core-01"
+  search       = "action:accept"
+  include_hits = true
+}
+
+output "never_hit_rules" {
+  value = [for rule in data.tufin_rules.core_accept.rules : rule.number if rule.last_hit == ""]
+}
+
+# Skip the access request when every device on the path already permits the flow
+data "tufin_policy_query" "web_to_db" {
+  sources      = ["10.10.0.0/24"]
+  destinations = ["10.20.0.15"]
+  services     = ["tcp 5432"]
+}
+
+resource "tufin_access_request" "web_to_db" {
+  count = data.tufin_policy_query.web_to_db.allowed ? 0 : 1
+
+  subject      = "App web tier to database"
+  sources      = ["10.10.0.0/24"]
+  destinations = ["10.20.0.15"]
+  services     = ["tcp 5432"]
+}
+
+# Let the new monitoring subnet use the existing SNMP rule instead of adding a rule
+resource "tufin_rule_modification" "snmp_monitoring" {
+  device        = "fw-core-01"
+  rule_uid      = "{8f0c6f1e-2a44-4d8b-9f3e-7d0b1c2e3f40}"
+  add_sources   = ["10.60.0.0/24"]
+  justification = "CHG0012399 new monitoring subnet"
+}
+
+# Disable rules with no hits, then remove them in a later cleanup cycle
+resource "tufin_rule_decommission" "unused" {
+  devices   = ["fw-core-01"]
+  rule_uids = [for rule in data.tufin_rules.core_accept.rules : rule.uid if rule.last_hit == ""]
+  action    = "disable"
+}
+
+data "tufin_unused_rules" "core" {
+  device      = "fw-core-01"
+  unused_days = 180
+}
+
+data "tufin_shadowed_rules" "core" {
+  device = "fw-core-01"
+}
+
+output "cleanup_candidates" {
+  value = distinct(concat(
+    data.tufin_unused_rules.core.rules[*].uid,
+    data.tufin_shadowed_rules.core.rules[*].uid,
+  ))
+}
+
+data "tufin_device_revisions" "core" {
+  device = "fw-core-01"
+  limit  = 2
+}
+
+# Rules changed by the most recent policy install
+data "tufin_revision_diff" "latest" {
+  old_revision = data.tufin_device_revisions.core.revisions[1].id
+  new_revision = data.tufin_device_revisions.core.revisions[0].id
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_service_object" "app_https" {
+  name     = "tcp_8443"
+  protocol = "tcp"
+  port     = "8443"
+  devices  = ["fw-core-01"]
+}
+
+resource "tufin_service_object" "app_range" {
+  name     = "tcp_9000-9010"
+  protocol = "tcp"
+  port     = "9000-9010"
+  devices  = ["fw-core-01"]
+}
+
+resource "tufin_service_group" "app" {
+  name    = "SG_app-prod"
+  members = [tufin_service_object.app_https.name, tufin_service_object.app_range.name]
+  devices = ["fw-core-01"]
+}
@@ -13,9 +13,40 @@ provider "tufin" {
   user = "example"
   password = "example"
   allow_insecure = true
+
+  ticket_subject_template = "[{{.Workspace}}] {{.Subject}}"
+  ticket_comment_template = "Terraform run {{.RunID}}: {{.Justification}}"
+
+  ticket_change_comments    = true
+  ticket_change_attachments = true
 }
 
 resource "tufin_group_member" "singleton" {
+  group_name    = "TEST-001"
+  ip_address    = "1.1.1.1"
+  justification   = "CHG0012345 new monitoring probe"
+  priority        = "High"
+  record_revision = true
+}
+
+resource "tufin_group_member" "subnet" {
   group_name = "TEST-001"
-  ip_address = "1.1.1.1"
+  ip_address = "10.20.0.0/24"
+}
+
+resource "tufin_group_member" "range" {
+  group_name = "TEST-001"
+  ip_address = "10.30.0.10-10.30.0.20"
+}
+
+resource "tufin_group_member" "named" {
+  group_name  = "TEST-001"
+  object_name = "web-prod-01"
+}
+
+resource "tufin_group_member" "new_host" {
+  group_name  = "TEST-001"
+  ip_address  = "10.40.0.15"
+  object_name = "H_app-prod-15"
+  comment     = "Managed by Terraform workspace ${terraform.workspace}"
 }
@@ -0,0 +1,41 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_ticket" "maintenance" {
+  workflow = "Maintenance Window"
+  step     = "Open request"
+  subject  = "Core firewall maintenance"
+  priority = "High"
+
+  field {
+    name  = "Business Justification"
+    type  = "text_area"
+    value = "Quarterly patching"
+  }
+
+  field {
+    name  = "Window Start"
+    type  = "date"
+    value = "2021-01-15"
+  }
+
+  field {
+    name   = "Affected Sites"
+    type   = "multiple_selection"
+    values = ["DC1", "DC2"]
+  }
+}
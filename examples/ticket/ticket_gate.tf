@@ -0,0 +1,13 @@
+data "tufin_ticket" "release_change" {
+  ticket_id = 4211
+}
+
+data "tufin_tickets" "open_changes" {
+  subject       = "Release 2021.01"
+  status        = "In Progress"
+  created_after = "2021-01-01"
+}
+
+output "release_change_approved" {
+  value = data.tufin_ticket.release_change.status == "Resolved"
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+data "tufin_topology_path" "web_to_db" {
+  source      = "10.10.0.15"
+  destination = "10.20.0.15"
+  service     = "tcp 5432"
+}
+
+# Only request access when the topology does not already allow the flow
+resource "tufin_access_request" "web_to_db" {
+  count = data.tufin_topology_path.web_to_db.traffic_allowed ? 0 : 1
+
+  subject      = "App web tier to database"
+  sources      = ["10.10.0.15"]
+  destinations = ["10.20.0.15"]
+  services     = ["tcp 5432"]
+}
+
+output "blocking_devices" {
+  value = [for device in data.tufin_topology_path.web_to_db.devices : device.name if !device.allowed]
+}
@@ -0,0 +1,58 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_usp_matrix" "segmentation" {
+  name = "Production segmentation"
+
+  cell {
+    from_zone = "APP_PROD"
+    to_zone   = "DB_PROD"
+    access    = "allow_only"
+    services  = ["tcp 5432"]
+    severity  = "critical"
+  }
+
+  cell {
+    from_zone   = "APP_DEV"
+    to_zone     = "APP_PROD"
+    access      = "block_all"
+    description = "Development never reaches production"
+  }
+}
+
+# Fail the plan if the proposed flow would break segmentation policy
+data "tufin_usp_violations" "new_flow" {
+  sources            = ["10.60.0.0/24"]
+  destinations       = ["10.50.0.10"]
+  services           = ["tcp 5432"]
+  severity_threshold = "high"
+}
+
+data "tufin_usp_violations" "edge_firewall" {
+  device = "edge-fw-01"
+}
+
+# Temporary exception for a migration window. Once it expires it shows up as drift until removed or extended.
+resource "tufin_usp_exception" "migration" {
+  name            = "DB migration window"
+  sources         = ["10.60.0.0/24"]
+  destinations    = ["10.50.0.10"]
+  services        = ["tcp 5432"]
+  justification   = "Data copy from the legacy cluster, CHG-1042"
+  requester       = "dba-team"
+  expiration_date = "2026-10-26"
+}
@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    tufin = {
+      source = "jgrancell/tufin"
+      version = "0.0.1"
+    }
+  }
+}
+
+provider "tufin" {
+  securetrack_host = "localhost:8888"
+  securechange_host = "localhost:8888"
+  user = "example"
+  password = "example"
+  allow_insecure = true
+}
+
+resource "tufin_zone" "app_prod" {
+  name    = "APP_PROD"
+  comment = "Production application tier"
+}
+
+# Classify a subnet provisioned elsewhere in this configuration into the zone
+resource "tufin_zone_entry" "app_prod_subnet" {
+  zone_id = tufin_zone.app_prod.id
+  subnet  = "10.50.0.0/24"
+  comment = "Managed by Terraform workspace ${terraform.workspace}"
+}
+
+data "tufin_zones" "prod" {
+  name            = "prod"
+  include_entries = true
+}
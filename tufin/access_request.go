@@ -0,0 +1,68 @@
+package tufin
+
+import (
+	"strconv"
+	"strings"
+)
+
+// accessRequestEndpoint converts a source or destination argument into an access request endpoint. Addresses
+// become IP or RANGE endpoints, "any" matches everything and anything else is treated as a network object name.
+func accessRequestEndpoint(value string) SecureChangeEndpoint {
+	if strings.EqualFold(value, "any") {
+		return SecureChangeEndpoint{Type: "ANY"}
+	}
+	addr, err := parseNetworkAddress(value)
+	if err != nil {
+		return SecureChangeEndpoint{Type: "Object", ObjectName: value}
+	}
+	if addr.LastIP != "" {
+		return SecureChangeEndpoint{Type: "RANGE", RangeFirstIP: addr.IP, RangeLastIP: addr.LastIP}
+	}
+	if !strings.Contains(addr.Mask, ".") {
+		cidr, _ := strconv.Atoi(addr.Mask)
+		return SecureChangeEndpoint{Type: "IP", IPAddress: addr.IP, Cidr: cidr}
+	}
+	return SecureChangeEndpoint{Type: "IP", IPAddress: addr.IP, Netmask: addr.Mask}
+}
+
+// accessRequestService converts a service argument such as "tcp 443", "udp 5000-5010", "icmp 8" or "any" into
+// an access request service, treating anything else as a service object name
+func accessRequestService(value string) SecureChangeService {
+	if strings.EqualFold(value, "any") {
+		return SecureChangeService{Type: "ANY"}
+	}
+	if service, err := topologyServiceDefinition(value); err == nil {
+		return SecureChangeService{Type: "PROTOCOL", Protocol: service.ObjectType(), Port: service.Port()}
+	}
+	return SecureChangeService{Type: "Object", ObjectName: value}
+}
+
+// buildAccessRequest assembles an access request from source, destination, service and target device lists
+func buildAccessRequest(order string, action string, comment string, sources []interface{}, destinations []interface{}, services []interface{}, targets []interface{}) SecureChangeAccessRequest {
+	request := SecureChangeAccessRequest{
+		Order:       order,
+		Action:      action,
+		Comment:     comment,
+		UseTopology: len(targets) == 0,
+	}
+	for _, source := range sources {
+		request.Sources.Source = append(request.Sources.Source, accessRequestEndpoint(source.(string)))
+	}
+	for _, destination := range destinations {
+		request.Destinations.Destination = append(request.Destinations.Destination, accessRequestEndpoint(destination.(string)))
+	}
+	for _, service := range services {
+		request.Services.Service = append(request.Services.Service, accessRequestService(service.(string)))
+	}
+	if len(targets) > 0 {
+		request.Targets = &SecureChangeTargets{}
+		for _, target := range targets {
+			request.Targets.Target = append(request.Targets.Target, SecureChangeTarget{
+				Type:           "Object",
+				ObjectName:     target.(string),
+				ManagementName: target.(string),
+			})
+		}
+	}
+	return request
+}
@@ -0,0 +1,180 @@
+package tufin
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// networkAddress represents a host, subnet or address range in the form SecureChange expects for group members
+type networkAddress struct {
+	Value         string
+	Type          string
+	ObjectType    string
+	ObjectDetails string
+	// IP is the host or network address, or the first address of a range
+	IP string
+	// Mask is the dotted IPv4 netmask or IPv6 prefix length, and is empty for ranges
+	Mask string
+	// LastIP is the final address of a range, and is empty otherwise
+	LastIP string
+}
+
+// parseNetworkAddress parses an IPv4/IPv6 host, a CIDR subnet or a start-end address range
+func parseNetworkAddress(value string) (*networkAddress, error) {
+	value = strings.TrimSpace(value)
+
+	if strings.Contains(value, "-") {
+		bounds := strings.SplitN(value, "-", 2)
+		start := net.ParseIP(strings.TrimSpace(bounds[0]))
+		end := net.ParseIP(strings.TrimSpace(bounds[1]))
+		if start == nil || end == nil {
+			return nil, fmt.Errorf("%s is not a valid address range", value)
+		}
+		if (start.To4() == nil) != (end.To4() == nil) {
+			return nil, fmt.Errorf("%s mixes IPv4 and IPv6 addresses", value)
+		}
+		if bytes.Compare(start.To16(), end.To16()) > 0 {
+			return nil, fmt.Errorf("%s starts after it ends", value)
+		}
+		return &networkAddress{
+			Value:         start.String() + "-" + end.String(),
+			Type:          "Range",
+			ObjectType:    "Address Range",
+			ObjectDetails: start.String() + "-" + end.String(),
+			IP:            start.String(),
+			LastIP:        end.String(),
+		}, nil
+	}
+
+	if strings.Contains(value, "/") {
+		ip, ipNet, err := net.ParseCIDR(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid CIDR subnet", value)
+		}
+		if !ip.Equal(ipNet.IP) {
+			return nil, fmt.Errorf("%s is not a network address, did you mean %s?", value, ipNet.String())
+		}
+		ones, bits := ipNet.Mask.Size()
+		if ones == bits {
+			return hostAddress(ip), nil
+		}
+		return &networkAddress{
+			Value:         ipNet.String(),
+			Type:          "Network",
+			ObjectType:    "Network",
+			ObjectDetails: ipNet.IP.String() + "/" + maskDetails(ipNet.IP, ipNet.Mask),
+			IP:            ipNet.IP.String(),
+			Mask:          maskDetails(ipNet.IP, ipNet.Mask),
+		}, nil
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not a valid IP address", value)
+	}
+	return hostAddress(ip), nil
+}
+
+// hostAddress builds a single host networkAddress for an IPv4 or IPv6 address
+func hostAddress(ip net.IP) *networkAddress {
+	bits := 128
+	if ip.To4() != nil {
+		bits = 32
+	}
+	mask := net.CIDRMask(bits, bits)
+	return &networkAddress{
+		Value:         ip.String(),
+		Type:          "Host",
+		ObjectType:    "Host",
+		ObjectDetails: ip.String() + "/" + maskDetails(ip, mask),
+		IP:            ip.String(),
+		Mask:          maskDetails(ip, mask),
+	}
+}
+
+// maskDetails renders a netmask as dotted decimal for IPv4 and as a prefix length for IPv6
+func maskDetails(ip net.IP, mask net.IPMask) string {
+	if ip.To4() != nil {
+		return net.IP(mask).To4().String()
+	}
+	ones, _ := mask.Size()
+	return strconv.Itoa(ones)
+}
+
+// validateNetworkAddress is a schema ValidateFunc for host, subnet and range arguments
+func validateNetworkAddress(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseNetworkAddress(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q is invalid: %s. May be an IPv4/IPv6 host, a CIDR subnet or a start-end range.", key, err))
+	}
+	return
+}
+
+// objectAddress renders a SecureTrack host or subnet object in the same notation parseNetworkAddress accepts,
+// returning an empty string for object types whose address SecureTrack does not expose
+func objectAddress(obj *tufinclient.SecureTrackNetworkObject) string {
+	ip := net.ParseIP(obj.IP)
+	if ip == nil {
+		return ""
+	}
+	if obj.Netmask == "" {
+		return ip.String()
+	}
+	mask := net.ParseIP(obj.Netmask)
+	if mask == nil {
+		return ""
+	}
+	if mask.To4() != nil {
+		mask = mask.To4()
+	}
+	ones, bits := net.IPMask(mask).Size()
+	if bits == 0 {
+		return ""
+	}
+	if ones == bits {
+		return ip.String()
+	}
+	return ip.String() + "/" + strconv.Itoa(ones)
+}
+
+// rangeAddress renders the bounds of an address range in the same notation parseNetworkAddress accepts, returning an
+// empty string when either bound is not an IP address
+func rangeAddress(first string, last string) string {
+	start := net.ParseIP(first)
+	end := net.ParseIP(last)
+	if start == nil || end == nil {
+		return ""
+	}
+	return start.String() + "-" + end.String()
+}
+
+// networkObjectAddress renders the address of a SecureTrack host, subnet or range object. Range bounds are not part
+// of the client's network object, so they are looked up separately.
+func networkObjectAddress(c *tufinclient.SecureTrackClient, obj *tufinclient.SecureTrackNetworkObject) (string, error) {
+	if obj.XsiType != "rangeNetworkObjectDTO" {
+		return objectAddress(obj), nil
+	}
+	r, err := getDeviceRangeObjectByUID(c, obj.UID, strconv.FormatInt(obj.DeviceID, 10))
+	if err != nil || r == nil {
+		return "", err
+	}
+	return rangeAddress(r.FirstIP, r.LastIP), nil
+}
+
+// suppressEquivalentAddress is a schema DiffSuppressFunc ignoring notation-only differences such as a /32 suffix
+func suppressEquivalentAddress(k, old, new string, d *schema.ResourceData) bool {
+	oldAddr, err := parseNetworkAddress(old)
+	if err != nil {
+		return false
+	}
+	newAddr, err := parseNetworkAddress(new)
+	if err != nil {
+		return false
+	}
+	return oldAddr.Value == newAddr.Value
+}
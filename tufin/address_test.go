@@ -0,0 +1,112 @@
+package tufin
+
+import (
+	"testing"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+func TestParseNetworkAddress(t *testing.T) {
+	cases := []struct {
+		value         string
+		valueOut      string
+		objectType    string
+		objectDetails string
+		mask          string
+		lastIP        string
+		err           bool
+	}{
+		{value: "10.1.2.3", valueOut: "10.1.2.3", objectType: "Host", objectDetails: "10.1.2.3/255.255.255.255", mask: "255.255.255.255"},
+		{value: " 10.1.2.3 ", valueOut: "10.1.2.3", objectType: "Host", objectDetails: "10.1.2.3/255.255.255.255", mask: "255.255.255.255"},
+		{value: "10.1.2.3/32", valueOut: "10.1.2.3", objectType: "Host", objectDetails: "10.1.2.3/255.255.255.255", mask: "255.255.255.255"},
+		{value: "10.1.2.0/24", valueOut: "10.1.2.0/24", objectType: "Network", objectDetails: "10.1.2.0/255.255.255.0", mask: "255.255.255.0"},
+		{value: "2001:db8::1", valueOut: "2001:db8::1", objectType: "Host", objectDetails: "2001:db8::1/128", mask: "128"},
+		{value: "2001:db8::/64", valueOut: "2001:db8::/64", objectType: "Network", objectDetails: "2001:db8::/64", mask: "64"},
+		{value: "10.1.2.3-10.1.2.9", valueOut: "10.1.2.3-10.1.2.9", objectType: "Address Range", objectDetails: "10.1.2.3-10.1.2.9", lastIP: "10.1.2.9"},
+		{value: "10.1.2.3 - 10.1.2.9", valueOut: "10.1.2.3-10.1.2.9", objectType: "Address Range", objectDetails: "10.1.2.3-10.1.2.9", lastIP: "10.1.2.9"},
+		{value: "10.1.2.9-10.1.2.3", err: true},
+		{value: "10.1.2.3-2001:db8::1", err: true},
+		{value: "10.1.2.1/24", err: true},
+		{value: "10.1.2.0/33", err: true},
+		{value: "256.1.2.3", err: true},
+		{value: "web-prod-01", err: true},
+		{value: "", err: true},
+	}
+
+	for _, c := range cases {
+		addr, err := parseNetworkAddress(c.value)
+		if c.err {
+			if err == nil {
+				t.Errorf("parseNetworkAddress(%q) succeeded, expected an error", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseNetworkAddress(%q) returned error: %s", c.value, err)
+			continue
+		}
+		if addr.Value != c.valueOut || addr.ObjectType != c.objectType || addr.ObjectDetails != c.objectDetails || addr.Mask != c.mask || addr.LastIP != c.lastIP {
+			t.Errorf("parseNetworkAddress(%q) = %+v", c.value, *addr)
+		}
+	}
+}
+
+func TestObjectAddress(t *testing.T) {
+	cases := []struct {
+		obj      tufinclient.SecureTrackNetworkObject
+		expected string
+	}{
+		{obj: tufinclient.SecureTrackNetworkObject{IP: "10.1.2.3"}, expected: "10.1.2.3"},
+		{obj: tufinclient.SecureTrackNetworkObject{IP: "10.1.2.3", Netmask: "255.255.255.255"}, expected: "10.1.2.3"},
+		{obj: tufinclient.SecureTrackNetworkObject{IP: "10.1.2.0", Netmask: "255.255.255.0"}, expected: "10.1.2.0/24"},
+		{obj: tufinclient.SecureTrackNetworkObject{IP: "2001:db8::", Netmask: "ffff:ffff:ffff:ffff::"}, expected: "2001:db8::/64"},
+		{obj: tufinclient.SecureTrackNetworkObject{IP: "10.1.2.0", Netmask: "not-a-mask"}, expected: ""},
+		{obj: tufinclient.SecureTrackNetworkObject{}, expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := objectAddress(&c.obj); actual != c.expected {
+			t.Errorf("objectAddress(%+v) = %q, expected %q", c.obj, actual, c.expected)
+		}
+	}
+}
+
+func TestValidateNetworkAddress(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{value: "10.1.2.3", valid: true},
+		{value: "10.1.2.0/24", valid: true},
+		{value: "2001:db8::/64", valid: true},
+		{value: "10.1.2.3-10.1.2.9", valid: true},
+		{value: "10.1.2.1/24", valid: false},
+		{value: "example.com", valid: false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateNetworkAddress(c.value, "ip_address")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("validateNetworkAddress(%q) returned %v, expected valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestRangeAddress(t *testing.T) {
+	cases := []struct {
+		first    string
+		last     string
+		expected string
+	}{
+		{first: "10.1.2.3", last: "10.1.2.9", expected: "10.1.2.3-10.1.2.9"},
+		{first: "2001:0db8::1", last: "2001:db8::ff", expected: "2001:db8::1-2001:db8::ff"},
+		{first: "10.1.2.3", last: "", expected: ""},
+		{first: "", last: "", expected: ""},
+	}
+
+	for _, c := range cases {
+		if actual := rangeAddress(c.first, c.last); actual != c.expected {
+			t.Errorf("rangeAddress(%q, %q) = %q, expected %q", c.first, c.last, actual, c.expected)
+		}
+	}
+}
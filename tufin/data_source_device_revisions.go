@@ -0,0 +1,104 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDeviceRevisions() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDeviceRevisionsRead,
+		Schema: ruleDeviceSchema(map[string]*schema.Schema{
+			"limit": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     0,
+				Description: "Only return this many of the newest revisions, or all of them when 0.",
+			},
+			"revisions": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"revision_number": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"admin": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"action": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"policy_package": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ticket_ids": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceDeviceRevisionsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceID, err := ruleDeviceID(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	revisions, err := getDeviceRevisions(&client.SecureTrack, deviceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if limit := d.Get("limit").(int); limit > 0 && limit < len(revisions) {
+		revisions = revisions[:limit]
+	}
+
+	flattened := make([]interface{}, 0, len(revisions))
+	for _, revision := range revisions {
+		tickets := make([]string, 0, len(revision.Tickets))
+		for _, ticket := range revision.Tickets {
+			tickets = append(tickets, ticket.ID)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":              strconv.FormatInt(revision.ID, 10),
+			"revision_number": revision.RevisionID,
+			"date":            strings.TrimSpace(revision.Date + " " + revision.Time),
+			"admin":           revision.Admin,
+			"action":          revision.Action,
+			"policy_package":  revision.PolicyPackage,
+			"ticket_ids":      tickets,
+		})
+	}
+
+	d.SetId(strconv.FormatInt(deviceID, 10))
+	d.Set("device_id", deviceID)
+	d.Set("revisions", flattened)
+
+	return diags
+}
@@ -0,0 +1,185 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePolicyQuery() *schema.Resource {
+	listOfStrings := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Required: required,
+			Optional: !required,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	return &schema.Resource{
+		ReadContext: dataSourcePolicyQueryRead,
+		Schema: map[string]*schema.Schema{
+			"devices":      listOfStrings(false),
+			"sources":      listOfStrings(true),
+			"destinations": listOfStrings(true),
+			"services":     listOfStrings(true),
+			"allowed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"verdict": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"device_results": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"device_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"device_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"allowed": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"rules": rulesSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePolicyQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sources, err := policyQueryAddresses(d.Get("sources").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	destinations, err := policyQueryAddresses(d.Get("destinations").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	services := []string{}
+	for _, s := range d.Get("services").([]interface{}) {
+		service, err := topologyService(s.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		services = append(services, service)
+	}
+
+	results, err := runPolicyAnalysis(&client.SecureTrack, deviceIDs, sources, destinations, services)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	allowedCount := 0
+	deviceResults := make([]interface{}, 0, len(results))
+	answered := map[string]bool{}
+	for _, result := range results {
+		answered[result.Device.ID] = true
+		rules := []SecureTrackRule{}
+		for _, binding := range result.BindingsAndRules {
+			rules = append(rules, binding.Rules...)
+		}
+		allowed := policyQueryAllows(result)
+		if allowed {
+			allowedCount++
+		}
+		deviceResults = append(deviceResults, map[string]interface{}{
+			"device_id":   result.Device.ID,
+			"device_name": result.Device.Name,
+			"allowed":     allowed,
+			"rules":       flattenRules(rules, nil),
+		})
+	}
+
+	// A requested device with no matching rule drops the flow through its implicit deny, so it counts as blocking
+	ids := make([]string, 0, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		id := strconv.FormatInt(deviceID, 10)
+		ids = append(ids, id)
+		if answered[id] {
+			continue
+		}
+		device, err := getDeviceByID(&client.SecureTrack, id)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		name := ""
+		if device != nil {
+			name = device.Name
+		}
+		deviceResults = append(deviceResults, map[string]interface{}{
+			"device_id":   id,
+			"device_name": name,
+			"allowed":     false,
+			"rules":       flattenRules(nil, nil),
+		})
+	}
+
+	verdict := "partially allowed"
+	switch {
+	case len(deviceResults) == 0:
+		verdict = "no matching rules"
+	case allowedCount == len(deviceResults):
+		verdict = "allowed"
+	case allowedCount == 0:
+		verdict = "blocked"
+	}
+
+	d.SetId(strings.Join([]string{strings.Join(ids, ","), strings.Join(sources, ","), strings.Join(destinations, ","), strings.Join(services, ",")}, "|"))
+	d.Set("allowed", verdict == "allowed")
+	d.Set("verdict", verdict)
+	d.Set("device_results", deviceResults)
+
+	return diags
+}
+
+// policyQueryAddresses normalises source or destination arguments, passing "any" through unchanged
+func policyQueryAddresses(values []interface{}) ([]string, error) {
+	addresses := make([]string, 0, len(values))
+	for _, v := range values {
+		if strings.EqualFold(v.(string), "any") {
+			addresses = append(addresses, "any")
+			continue
+		}
+		addr, err := parseNetworkAddress(v.(string))
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, addr.Value)
+	}
+	return addresses, nil
+}
+
+// policyQueryAllows reports whether a device permits the queried flow through every binding it matched in
+func policyQueryAllows(result SecureTrackDeviceAndBindings) bool {
+	actions := make([]string, 0, len(result.BindingsAndRules))
+	for _, binding := range result.BindingsAndRules {
+		action := ""
+		if len(binding.Rules) > 0 {
+			action = binding.Rules[0].Action
+		}
+		actions = append(actions, action)
+	}
+	return bindingsAllow(actions)
+}
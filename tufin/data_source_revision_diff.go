@@ -0,0 +1,86 @@
+package tufin
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRevisionDiff() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRevisionDiffRead,
+		Schema: map[string]*schema.Schema{
+			"old_revision": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"new_revision": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"added_rules":    rulesSchema(),
+			"removed_rules":  rulesSchema(),
+			"modified_rules": rulesSchema(),
+		},
+	}
+}
+
+func dataSourceRevisionDiffRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	oldRevision := d.Get("old_revision").(string)
+	newRevision := d.Get("new_revision").(string)
+
+	// Revision rules are addressed by revision alone, so no device is needed
+	oldRules, err := secureTrack(&client.SecureTrack).GetDeviceRules(0, oldRevision)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	newRules, err := secureTrack(&client.SecureTrack).GetDeviceRules(0, newRevision)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	added, removed, modified := diffRules(oldRules, newRules)
+
+	d.SetId(oldRevision + ".." + newRevision)
+	d.Set("added_rules", flattenRules(added, nil))
+	d.Set("removed_rules", flattenRules(removed, nil))
+	d.Set("modified_rules", flattenRules(modified, nil))
+
+	return diags
+}
+
+// diffRules compares two revisions of a policy by rule UID, returning the rules only in the new revision, those only
+// in the old one, and the new state of rules whose content changed. Rule IDs and numbers change between revisions
+// and so are ignored.
+func diffRules(oldRules []SecureTrackRule, newRules []SecureTrackRule) (added []SecureTrackRule, removed []SecureTrackRule, modified []SecureTrackRule) {
+	old := map[string]SecureTrackRule{}
+	for _, rule := range oldRules {
+		old[rule.UID] = rule
+	}
+	seen := map[string]bool{}
+	for _, rule := range newRules {
+		seen[rule.UID] = true
+		previous, ok := old[rule.UID]
+		if !ok {
+			added = append(added, rule)
+			continue
+		}
+		if previous.Action != rule.Action || previous.Disabled != rule.Disabled || previous.Comment != rule.Comment ||
+			!reflect.DeepEqual(ruleObjectNames(previous.SrcNetwork), ruleObjectNames(rule.SrcNetwork)) ||
+			!reflect.DeepEqual(ruleObjectNames(previous.DstNetwork), ruleObjectNames(rule.DstNetwork)) ||
+			!reflect.DeepEqual(ruleObjectNames(previous.DstService), ruleObjectNames(rule.DstService)) {
+			modified = append(modified, rule)
+		}
+	}
+	for _, rule := range oldRules {
+		if !seen[rule.UID] {
+			removed = append(removed, rule)
+		}
+	}
+	return added, removed, modified
+}
@@ -0,0 +1,79 @@
+package tufin
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffRules(t *testing.T) {
+	web := []SecureTrackRuleObject{{Name: "web", DisplayName: "web"}}
+	db := []SecureTrackRuleObject{{Name: "db", DisplayName: "db"}}
+	https := []SecureTrackRuleObject{{Name: "https", DisplayName: "https"}}
+	rule := func(uid string, id int64, number int, action string, dst []SecureTrackRuleObject) SecureTrackRule {
+		return SecureTrackRule{ID: id, UID: uid, RuleNumber: number, Action: action, SrcNetwork: web, DstNetwork: dst, DstService: https}
+	}
+
+	cases := []struct {
+		name     string
+		oldRules []SecureTrackRule
+		newRules []SecureTrackRule
+		added    []string
+		removed  []string
+		modified []string
+	}{
+		{
+			name:     "identical",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+			newRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+		},
+		{
+			name:     "renumbered rules are unchanged",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+			newRules: []SecureTrackRule{rule("a", 7, 3, "accept", db)},
+		},
+		{
+			name:     "added and removed",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+			newRules: []SecureTrackRule{rule("b", 2, 1, "accept", db)},
+			added:    []string{"b"},
+			removed:  []string{"a"},
+		},
+		{
+			name:     "action changed",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+			newRules: []SecureTrackRule{rule("a", 1, 1, "drop", db)},
+			modified: []string{"a"},
+		},
+		{
+			name:     "destination changed",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db), rule("b", 2, 2, "accept", db)},
+			newRules: []SecureTrackRule{rule("a", 1, 1, "accept", web), rule("b", 2, 2, "accept", db)},
+			modified: []string{"a"},
+		},
+		{
+			name:     "disabled",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db)},
+			newRules: []SecureTrackRule{{ID: 1, UID: "a", RuleNumber: 1, Action: "accept", Disabled: true, SrcNetwork: web, DstNetwork: db, DstService: https}},
+			modified: []string{"a"},
+		},
+		{
+			name:     "empty new revision",
+			oldRules: []SecureTrackRule{rule("a", 1, 1, "accept", db), rule("b", 2, 2, "accept", db)},
+			removed:  []string{"a", "b"},
+		},
+	}
+
+	uids := func(rules []SecureTrackRule) []string {
+		var uids []string
+		for _, rule := range rules {
+			uids = append(uids, rule.UID)
+		}
+		return uids
+	}
+	for _, c := range cases {
+		added, removed, modified := diffRules(c.oldRules, c.newRules)
+		if !reflect.DeepEqual(uids(added), c.added) || !reflect.DeepEqual(uids(removed), c.removed) || !reflect.DeepEqual(uids(modified), c.modified) {
+			t.Errorf("%s: diffRules added %v, removed %v, modified %v, expected %v, %v, %v", c.name, uids(added), uids(removed), uids(modified), c.added, c.removed, c.modified)
+		}
+	}
+}
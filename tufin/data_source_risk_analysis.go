@@ -0,0 +1,162 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// riskSeverities orders the security policy violation severities SecureChange reports, lowest first
+var riskSeverities = []string{"low", "medium", "high", "critical"}
+
+func dataSourceRiskAnalysis() *schema.Resource {
+	listOfStrings := func(required bool) *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Required: required,
+			Optional: !required,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	return &schema.Resource{
+		Description: "Checks an access request against the Unified Security Policy without opening a ticket.",
+		ReadContext: dataSourceRiskAnalysisRead,
+		Schema: map[string]*schema.Schema{
+			"sources":      listOfStrings(true),
+			"destinations": listOfStrings(true),
+			"services":     listOfStrings(true),
+			"targets":      listOfStrings(false),
+			"action": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Accept",
+				ValidateFunc: validateRuleAction,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"severity_threshold": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "high",
+				ValidateFunc: validateRiskSeverity,
+				Description:  "Violations of at least this severity fail the plan, while less severe ones are reported as warnings.",
+			},
+			"violations": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"severity": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"matrix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"from_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRiskAnalysisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	// SecureTrack checks the flow directly, so reading this during every plan leaves nothing behind in SecureChange
+	request := buildAccessRequest(
+		"AR1",
+		d.Get("action").(string),
+		d.Get("comment").(string),
+		d.Get("sources").([]interface{}),
+		d.Get("destinations").([]interface{}),
+		d.Get("services").([]interface{}),
+		d.Get("targets").([]interface{}),
+	)
+	found, err := getAccessRequestViolations(&client.SecureTrack, request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	threshold := riskSeverityLevel(d.Get("severity_threshold").(string))
+	violations := make([]interface{}, 0, len(found))
+	for _, violation := range found {
+		violations = append(violations, map[string]interface{}{
+			"severity":    strings.ToLower(violation.Severity),
+			"matrix":      violation.SecurityZoneMatrix.Name,
+			"from_zone":   violation.MatrixCellViolation.FromZone,
+			"to_zone":     violation.MatrixCellViolation.ToZone,
+			"description": violation.Description,
+		})
+
+		severity := diag.Warning
+		if riskSeverityLevel(violation.Severity) >= threshold {
+			severity = diag.Error
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary: fmt.Sprintf("%s severity security policy violation from %s to %s",
+				riskSeverityLabel(violation.Severity),
+				violation.MatrixCellViolation.FromZone,
+				violation.MatrixCellViolation.ToZone),
+			Detail: fmt.Sprintf("Risk analysis found a violation of security zone matrix %s. %s",
+				violation.SecurityZoneMatrix.Name, violation.Description),
+		})
+	}
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+	d.Set("violations", violations)
+
+	return diags
+}
+
+// riskSeverityLevel ranks a violation severity, placing unrecognised severities above all known ones
+func riskSeverityLevel(severity string) int {
+	for i, s := range riskSeverities {
+		if strings.EqualFold(s, severity) {
+			return i
+		}
+	}
+	return len(riskSeverities)
+}
+
+// riskSeverityLabel capitalises a severity for diagnostics, such as High for HIGH
+func riskSeverityLabel(severity string) string {
+	severity = strings.ToLower(severity)
+	if severity == "" {
+		return severity
+	}
+	return strings.ToUpper(severity[:1]) + severity[1:]
+}
+
+// validateRiskSeverity is a schema ValidateFunc for risk severity thresholds
+func validateRiskSeverity(val interface{}, key string) (warns []string, errs []error) {
+	if riskSeverityLevel(val.(string)) == len(riskSeverities) {
+		errs = append(errs, fmt.Errorf("%q must be one of %s.", key, strings.Join(riskSeverities, ", ")))
+	}
+	return
+}
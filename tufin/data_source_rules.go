@@ -0,0 +1,69 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRulesRead,
+		Schema: ruleDeviceSchema(map[string]*schema.Schema{
+			"revision": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Revision ID to read the rules from, defaulting to the device's current policy.",
+			},
+			"search": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "SecureTrack rule search text, such as \"action:accept source:10.0.0.1\".",
+			},
+			"include_hits": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rules": rulesSchema(),
+		}),
+	}
+}
+
+func dataSourceRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceID, err := ruleDeviceID(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	revision := d.Get("revision").(string)
+
+	var rules []SecureTrackRule
+	if search := d.Get("search").(string); search != "" {
+		rules, err = secureTrack(&client.SecureTrack).SearchDeviceRules(deviceID, revision, search)
+	} else {
+		rules, err = secureTrack(&client.SecureTrack).GetDeviceRules(deviceID, revision)
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hits := map[string]string{}
+	if d.Get("include_hits").(bool) {
+		hits, err = getRuleLastHits(&client.SecureTrack, deviceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(strconv.FormatInt(deviceID, 10) + "/" + revision)
+	d.Set("device_id", deviceID)
+	d.Set("rules", flattenRules(rules, hits))
+
+	return diags
+}
@@ -0,0 +1,82 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceShadowedRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceShadowedRulesRead,
+		Schema: ruleDeviceSchema(map[string]*schema.Schema{
+			"rules": rulesSchema(),
+			"shadowing": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"rule_uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"shadowed_by": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		}),
+	}
+}
+
+func dataSourceShadowedRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceID, err := ruleDeviceID(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := secureTrack(&client.SecureTrack).GetDeviceRules(deviceID, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	uids := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		uids = append(uids, rule.UID)
+	}
+
+	shadowed, err := getShadowedRules(&client.SecureTrack, deviceID, uids)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	shadowedRules := make([]SecureTrackRule, 0, len(shadowed))
+	shadowing := make([]interface{}, 0, len(shadowed))
+	for _, s := range shadowed {
+		shadowedRules = append(shadowedRules, s.Rule)
+		by := make([]string, 0, len(s.ShadowingRules.Rule))
+		for _, rule := range s.ShadowingRules.Rule {
+			by = append(by, rule.UID)
+		}
+		shadowing = append(shadowing, map[string]interface{}{
+			"rule_uid":    s.Rule.UID,
+			"shadowed_by": by,
+		})
+	}
+
+	d.SetId(strconv.FormatInt(deviceID, 10))
+	d.Set("device_id", deviceID)
+	d.Set("rules", flattenRules(shadowedRules, nil))
+	d.Set("shadowing", shadowing)
+
+	return diags
+}
@@ -0,0 +1,101 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTicket() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTicketRead,
+		Schema: map[string]*schema.Schema{
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"priority": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"requester": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_step": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"steps": ticketStepsSchema(),
+			"fields": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"step": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTicketRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	ticketID := int64(d.Get("ticket_id").(int))
+
+	ticket, err := secureChange(&client.SecureChange).GetTicket(ticketID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ticket == nil {
+		return diag.Errorf("Ticket %d does not exist in SecureChange", ticketID)
+	}
+
+	d.SetId(strconv.FormatInt(ticketID, 10))
+	d.Set("subject", ticket.Subject)
+	d.Set("status", ticket.Status)
+	d.Set("priority", ticket.Priority)
+	d.Set("requester", ticket.Requester)
+	d.Set("workflow", ticket.Workflow.Name)
+	if ticket.CurrentStep != nil {
+		d.Set("current_step", ticket.CurrentStep.Name)
+	} else {
+		d.Set("current_step", "")
+	}
+	d.Set("steps", flattenTicketSteps(ticket))
+	d.Set("fields", flattenTicketFields(ticket))
+
+	return diags
+}
@@ -0,0 +1,142 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTickets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTicketsRead,
+		Schema: map[string]*schema.Schema{
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"requester": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"created_after": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTicketDate,
+			},
+			"created_before": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateTicketDate,
+			},
+			"tickets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"subject": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"priority": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"requester": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"workflow": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"current_step": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"create_date": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTicketsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	results, err := secureChange(&client.SecureChange).SearchTickets(
+		d.Get("subject").(string),
+		d.Get("status").(string),
+		d.Get("requester").(string),
+		d.Get("workflow").(string),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The search API has no date filters, so the created_after/created_before range is applied here
+	after, hasAfter := d.GetOk("created_after")
+	before, hasBefore := d.GetOk("created_before")
+
+	tickets := []interface{}{}
+	for _, result := range results {
+		if hasAfter || hasBefore {
+			created, err := parseTicketDate(result.CreateDate)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if hasAfter {
+				from, _ := parseTicketDate(after.(string))
+				if created.Before(from) {
+					continue
+				}
+			}
+			if hasBefore {
+				to, _ := ticketDateEnd(before.(string))
+				if created.After(to) {
+					continue
+				}
+			}
+		}
+		tickets = append(tickets, map[string]interface{}{
+			"id":           int(result.ID),
+			"subject":      result.Subject,
+			"status":       result.Status,
+			"priority":     result.Priority,
+			"requester":    result.Requester,
+			"workflow":     result.WorkflowName,
+			"current_step": result.CurrentStep,
+			"create_date":  result.CreateDate,
+		})
+	}
+
+	// Search results have no natural identity, so the ID only marks when the search last ran
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	d.Set("tickets", tickets)
+
+	return diags
+}
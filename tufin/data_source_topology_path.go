@@ -0,0 +1,204 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceTopologyPath() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTopologyPathRead,
+		Schema: map[string]*schema.Schema{
+			"source": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateTopologyAddress,
+			},
+			"destination": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateTopologyAddress,
+			},
+			"service": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "any",
+				Description: "Service such as \"tcp 443\", \"udp 5000-5010\" or \"icmp 8\", or \"any\".",
+			},
+			"include_incomplete_paths": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"traffic_allowed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"vendor": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"incoming_interfaces": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"outgoing_interfaces": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"rules": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"allowed": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTopologyPathRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	source, err := parseNetworkAddress(d.Get("source").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	destination, err := parseNetworkAddress(d.Get("destination").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	service, err := topologyService(d.Get("service").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path, err := getTopologyPath(&client.SecureTrack, source.Value, destination.Value, service, d.Get("include_incomplete_paths").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	devices := make([]interface{}, 0, len(path.DeviceInfo))
+	for _, device := range path.DeviceInfo {
+		incoming := []string{}
+		for _, iface := range device.IncomingInterfaces {
+			incoming = append(incoming, iface.Name)
+		}
+		outgoing := []string{}
+		for _, next := range device.NextDevices {
+			for _, route := range next.Routes {
+				outgoing = append(outgoing, route.OutgoingInterfaceName)
+			}
+		}
+		rules := []string{}
+		for _, binding := range device.Bindings {
+			for _, rule := range binding.Rules {
+				rules = append(rules, fmt.Sprint(rule.RuleIdentifier))
+			}
+		}
+		devices = append(devices, map[string]interface{}{
+			"id":                  device.ID,
+			"name":                device.Name,
+			"vendor":              device.Vendor,
+			"incoming_interfaces": incoming,
+			"outgoing_interfaces": outgoing,
+			"rules":               rules,
+			"allowed":             pathDeviceAllows(device),
+		})
+	}
+
+	d.SetId(strings.Join([]string{source.Value, destination.Value, service}, "|"))
+	d.Set("traffic_allowed", path.TrafficAllowed)
+	d.Set("devices", devices)
+
+	return diags
+}
+
+// validateTopologyAddress checks a path endpoint, which the topology API takes as a host or subnet but not a range
+func validateTopologyAddress(val interface{}, key string) (warns []string, errs []error) {
+	addr, err := parseNetworkAddress(val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q is invalid: %s. May be an IPv4/IPv6 host or CIDR subnet.", key, err))
+	} else if addr.LastIP != "" {
+		errs = append(errs, fmt.Errorf("%q cannot be an address range.", key))
+	}
+	return
+}
+
+// topologyService converts a service argument such as "tcp 443" into the protocol:port form the topology API takes
+func topologyService(value string) (string, error) {
+	if strings.EqualFold(strings.TrimSpace(value), "any") {
+		return "any", nil
+	}
+	service, err := topologyServiceDefinition(value)
+	if err != nil {
+		return "", err
+	}
+	return service.Protocol + ":" + service.Port(), nil
+}
+
+// topologyServiceDefinition parses a service argument such as "tcp 443", "udp 5000-5010" or "icmp 8"
+func topologyServiceDefinition(value string) (*serviceDefinition, error) {
+	parts := strings.Fields(value)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Service %q must be a protocol and port, such as \"tcp 443\", or \"any\"", value)
+	}
+	if strings.EqualFold(parts[0], "icmp") {
+		icmpType, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("Service %q has an invalid icmp type", value)
+		}
+		return parseServiceDefinition(parts[0], "", icmpType)
+	}
+	return parseServiceDefinition(parts[0], parts[1], 0)
+}
+
+// pathDeviceAllows reports whether a device along a path lets the traffic through its bindings. Devices without
+// policy bindings, such as routers, only forward.
+func pathDeviceAllows(device SecureTrackPathDevice) bool {
+	if len(device.Bindings) == 0 {
+		return true
+	}
+	actions := make([]string, 0, len(device.Bindings))
+	for _, binding := range device.Bindings {
+		action := ""
+		if len(binding.Rules) > 0 {
+			action = binding.Rules[0].Action
+		}
+		actions = append(actions, action)
+	}
+	return bindingsAllow(actions)
+}
@@ -0,0 +1,88 @@
+package tufin
+
+import (
+	"testing"
+)
+
+func TestValidateTopologyAddress(t *testing.T) {
+	cases := []struct {
+		value string
+		valid bool
+	}{
+		{value: "10.1.2.3", valid: true},
+		{value: "10.1.2.0/24", valid: true},
+		{value: "2001:db8::/64", valid: true},
+		{value: "10.1.2.3-10.1.2.9", valid: false},
+		{value: "example.com", valid: false},
+	}
+
+	for _, c := range cases {
+		_, errs := validateTopologyAddress(c.value, "source")
+		if (len(errs) == 0) != c.valid {
+			t.Errorf("validateTopologyAddress(%q) returned %v, expected valid = %t", c.value, errs, c.valid)
+		}
+	}
+}
+
+func TestTopologyService(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected string
+		err      bool
+	}{
+		{value: "any", expected: "any"},
+		{value: " ANY ", expected: "any"},
+		{value: "tcp 443", expected: "tcp:443"},
+		{value: "udp 5000-5010", expected: "udp:5000-5010"},
+		{value: "icmp 8", expected: "icmp:8"},
+		{value: "icmp 8x", err: true},
+		{value: "icmp echo", err: true},
+		{value: "tcp", err: true},
+		{value: "https", err: true},
+	}
+
+	for _, c := range cases {
+		actual, err := topologyService(c.value)
+		if c.err {
+			if err == nil {
+				t.Errorf("topologyService(%q) succeeded, expected an error", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("topologyService(%q) returned error: %s", c.value, err)
+			continue
+		}
+		if actual != c.expected {
+			t.Errorf("topologyService(%q) = %q, expected %q", c.value, actual, c.expected)
+		}
+	}
+}
+
+func TestPathDeviceAllows(t *testing.T) {
+	binding := func(actions ...string) SecureTrackPathBinding {
+		binding := SecureTrackPathBinding{Rules: []SecureTrackPathRule{}}
+		for _, action := range actions {
+			binding.Rules = append(binding.Rules, SecureTrackPathRule{Action: action})
+		}
+		return binding
+	}
+
+	cases := []struct {
+		name     string
+		bindings []SecureTrackPathBinding
+		allowed  bool
+	}{
+		{name: "router", allowed: true},
+		{name: "first rule accepts", bindings: []SecureTrackPathBinding{binding("accept", "drop")}, allowed: true},
+		{name: "first rule drops", bindings: []SecureTrackPathBinding{binding("drop", "accept")}, allowed: false},
+		{name: "no matching rule", bindings: []SecureTrackPathBinding{binding()}, allowed: false},
+		{name: "one binding drops", bindings: []SecureTrackPathBinding{binding("permit"), binding("deny")}, allowed: false},
+	}
+
+	for _, c := range cases {
+		if actual := pathDeviceAllows(SecureTrackPathDevice{Bindings: c.bindings}); actual != c.allowed {
+			t.Errorf("%s: pathDeviceAllows = %t, expected %t", c.name, actual, c.allowed)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUnusedRules() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceUnusedRulesRead,
+		Schema: ruleDeviceSchema(map[string]*schema.Schema{
+			"unused_days": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     90,
+				Description: "Rules not hit within this many days, or never hit at all, are reported.",
+			},
+			"include_disabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"rules": rulesSchema(),
+		}),
+	}
+}
+
+func dataSourceUnusedRulesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceID, err := ruleDeviceID(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rules, err := secureTrack(&client.SecureTrack).GetDeviceRules(deviceID, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	hits, err := getRuleLastHits(&client.SecureTrack, deviceID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	since := time.Now().AddDate(0, 0, -d.Get("unused_days").(int))
+	unused := []SecureTrackRule{}
+	for _, rule := range rules {
+		if rule.Disabled && !d.Get("include_disabled").(bool) {
+			continue
+		}
+		if hit, ok := hits[rule.UID]; ok && hit != "" {
+			// Hits in a format we cannot read are treated as recent, so rules are never reported unused by mistake
+			lastHit, err := parseTicketDate(hit)
+			if err != nil || lastHit.After(since) {
+				continue
+			}
+		}
+		unused = append(unused, rule)
+	}
+
+	d.SetId(strconv.FormatInt(deviceID, 10) + "/" + strconv.Itoa(d.Get("unused_days").(int)))
+	d.Set("device_id", deviceID)
+	d.Set("rules", flattenRules(unused, hits))
+
+	return diags
+}
@@ -0,0 +1,187 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceUSPViolations() *schema.Resource {
+	flow := func(required []string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			RequiredWith: required,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	return &schema.Resource{
+		ReadContext: dataSourceUSPViolationsRead,
+		Schema: map[string]*schema.Schema{
+			"device": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"device", "device_id", "sources"},
+				Description:  "Name or IP of a SecureTrack device whose current rules are checked.",
+			},
+			"device_id": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"device", "device_id", "sources"},
+				Description:  "SecureTrack ID of a device whose current rules are checked.",
+			},
+			"sources": &schema.Schema{
+				Type:         schema.TypeList,
+				Optional:     true,
+				ExactlyOneOf: []string{"device", "device_id", "sources"},
+				RequiredWith: []string{"destinations", "services"},
+				Description:  "Sources of a proposed flow to check instead of a device.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"destinations": flow([]string{"sources"}),
+			"services":     flow([]string{"sources"}),
+			"action": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Accept",
+			},
+			"severity_threshold": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRiskSeverity,
+				Description:  "Violations of at least this severity fail the plan, while less severe ones are reported as warnings.",
+			},
+			"violated": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"violations": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"severity": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"matrix": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"from_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"to_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"rule_uid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceUSPViolationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) (diags diag.Diagnostics) {
+	client := m.(*providerMeta).Client
+
+	violations := []interface{}{}
+	if _, ok := d.GetOk("sources"); ok {
+		request := buildAccessRequest(
+			"AR1",
+			d.Get("action").(string),
+			"",
+			d.Get("sources").([]interface{}),
+			d.Get("destinations").([]interface{}),
+			d.Get("services").([]interface{}),
+			[]interface{}{},
+		)
+		found, err := getAccessRequestViolations(&client.SecureTrack, request)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, violation := range found {
+			violations = append(violations, map[string]interface{}{
+				"severity":    strings.ToLower(violation.Severity),
+				"matrix":      violation.SecurityZoneMatrix.Name,
+				"from_zone":   violation.MatrixCellViolation.FromZone,
+				"to_zone":     violation.MatrixCellViolation.ToZone,
+				"rule_uid":    "",
+				"description": violation.Description,
+			})
+		}
+
+		newUuid, _ := uuid.GenerateUUID()
+		d.SetId(newUuid)
+	} else {
+		deviceID, err := ruleDeviceID(&client.SecureTrack, d)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rules, err := getDeviceViolations(&client.SecureTrack, deviceID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, rule := range rules {
+			for _, violation := range rule.Violations.Violation {
+				violations = append(violations, map[string]interface{}{
+					"severity":    strings.ToLower(violation.Severity),
+					"matrix":      violation.SecurityPolicyName,
+					"from_zone":   violation.FromZone,
+					"to_zone":     violation.ToZone,
+					"rule_uid":    rule.UID,
+					"description": violation.Description,
+				})
+			}
+		}
+
+		d.SetId(strconv.FormatInt(deviceID, 10))
+		d.Set("device_id", deviceID)
+	}
+	d.Set("violated", len(violations) > 0)
+	d.Set("violations", violations)
+
+	// Without a threshold the violations are only reported as attributes, leaving the decision to the configuration
+	threshold, ok := d.GetOk("severity_threshold")
+	if !ok {
+		return diags
+	}
+	for _, v := range violations {
+		violation := v.(map[string]interface{})
+		severity := diag.Warning
+		if riskSeverityLevel(violation["severity"].(string)) >= riskSeverityLevel(threshold.(string)) {
+			severity = diag.Error
+		}
+		detail := fmt.Sprintf("Violates security zone matrix %s. %s", violation["matrix"], violation["description"])
+		if violation["rule_uid"] != "" {
+			detail = fmt.Sprintf("Rule %s violates security zone matrix %s. %s", violation["rule_uid"], violation["matrix"], violation["description"])
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: severity,
+			Summary: fmt.Sprintf("%s severity security policy violation from %s to %s",
+				riskSeverityLabel(violation["severity"].(string)), violation["from_zone"], violation["to_zone"]),
+			Detail: detail,
+		})
+	}
+
+	return diags
+}
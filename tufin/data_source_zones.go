@@ -0,0 +1,106 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceZones() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceZonesRead,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return zones whose name contains this text, ignoring case.",
+			},
+			"include_entries": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"zones": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"comment": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"shared": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"internet": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"subnets": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceZonesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	name := strings.ToLower(d.Get("name").(string))
+
+	zones, err := getZones(&client.SecureTrack)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattened := make([]interface{}, 0, len(zones))
+	for _, zone := range zones {
+		if !strings.Contains(strings.ToLower(zone.Name), name) {
+			continue
+		}
+		id := strconv.FormatInt(zone.ID, 10)
+		subnets := []string{}
+		if d.Get("include_entries").(bool) {
+			entries, err := getZoneEntries(&client.SecureTrack, id)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			for i := range entries {
+				subnets = append(subnets, zoneEntryAddress(&entries[i]))
+			}
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"id":       id,
+			"name":     zone.Name,
+			"comment":  zone.Comment,
+			"shared":   zone.Shared,
+			"internet": zone.Internet,
+			"subnets":  subnets,
+		})
+	}
+
+	d.SetId("zones/" + name)
+	d.Set("zones", flattened)
+
+	return diags
+}
@@ -0,0 +1,195 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// groupChangeKey identifies the group and device a batch of member changes applies to
+type groupChangeKey struct {
+	Group        string
+	ManagementID int64
+}
+
+// groupChangeBatch collects member changes until its window closes and then shares the resulting ticket and its outcome
+type groupChangeBatch struct {
+	members []SecureChangeGroupMember
+	// waiters counts the resources waiting on each queued member change
+	waiters []int
+	opts    ticketOptions
+	// flushed is set once the batch has left the pending map and its members can no longer change
+	flushed bool
+	// active counts the resources still waiting on the batch, and the outcome wait is cancelled once none are
+	active   int
+	ctx      context.Context
+	cancel   context.CancelFunc
+	done     chan struct{}
+	ticketID int64
+	err      error
+}
+
+// groupChangeBatcher coalesces concurrent member changes to the same group and device into one ticket
+type groupChangeBatcher struct {
+	client  *tufinclient.TufinClient
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[groupChangeKey]*groupChangeBatch
+}
+
+// newGroupChangeBatcher creates a batcher that submits each batch window after its first member change
+func newGroupChangeBatcher(client *tufinclient.TufinClient, window time.Duration) *groupChangeBatcher {
+	return &groupChangeBatcher{
+		client:  client,
+		window:  window,
+		pending: map[groupChangeKey]*groupChangeBatch{},
+	}
+}
+
+// Submit queues a member change and blocks until the shared ticket carrying it is implemented, rejected or cancelled.
+// The first change in a batch decides the ticket's priority and requester, while every distinct justification and
+// planned change is kept. When every resource waiting on a ticket gives up, the ticket is cancelled.
+func (b *groupChangeBatcher) Submit(ctx context.Context, member *SecureChangeGroupMember, group string, managementID string, opts ticketOptions) (int64, error) {
+	mgmtID, err := strconv.ParseInt(managementID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not convert management_id %s to integer", managementID)
+	}
+	key := groupChangeKey{Group: group, ManagementID: mgmtID}
+
+	b.mu.Lock()
+	batch, ok := b.pending[key]
+	if !ok {
+		batch = &groupChangeBatch{done: make(chan struct{}), opts: opts}
+		batch.opts.Warnings = &ticketWarnings{}
+		batch.ctx, batch.cancel = context.WithCancel(context.Background())
+		b.pending[key] = batch
+		time.AfterFunc(b.window, func() { b.flush(key) })
+	}
+	// A ticket cannot both add and remove the same member, and the order the two were meant to happen in is lost
+	for _, queued := range batch.members {
+		if queued.Name == member.Name && queued.Status != member.Status {
+			b.mu.Unlock()
+			return 0, fmt.Errorf("Member %s is both added to and removed from group %s on device %s in the same batch", member.Name, group, managementID)
+		}
+	}
+	if ok {
+		if opts.Justification != "" && !strings.Contains(batch.opts.Justification, opts.Justification) {
+			if batch.opts.Justification != "" {
+				batch.opts.Justification += "; "
+			}
+			batch.opts.Justification += opts.Justification
+		}
+		batch.opts.Changes = append(batch.opts.Changes, opts.Changes...)
+	}
+	if i := batchMemberIndex(batch, member); i >= 0 {
+		batch.waiters[i]++
+	} else {
+		batch.members = append(batch.members, *member)
+		batch.waiters = append(batch.waiters, 1)
+	}
+	batch.active++
+	b.mu.Unlock()
+
+	select {
+	case <-batch.done:
+		for _, warning := range batch.opts.Warnings.Diagnostics() {
+			opts.Warnings.add(warning.Summary, warning.Detail)
+		}
+		return batch.ticketID, batch.err
+	case <-ctx.Done():
+		b.leave(batch, member)
+		return 0, ctx.Err()
+	}
+}
+
+// leave stops a resource waiting on a batch. Its member change is taken out of a batch that has not been submitted
+// yet, and once no resource is left waiting the shared ticket is cancelled.
+func (b *groupChangeBatcher) leave(batch *groupChangeBatch, member *SecureChangeGroupMember) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if i := batchMemberIndex(batch, member); i >= 0 && !batch.flushed {
+		batch.waiters[i]--
+		if batch.waiters[i] == 0 {
+			batch.members = append(batch.members[:i], batch.members[i+1:]...)
+			batch.waiters = append(batch.waiters[:i], batch.waiters[i+1:]...)
+		}
+	}
+	batch.active--
+	if batch.active == 0 {
+		batch.cancel()
+	}
+}
+
+// flush submits every member change queued for key as a single group change ticket and waits for its outcome
+func (b *groupChangeBatcher) flush(key groupChangeKey) {
+	b.mu.Lock()
+	batch := b.pending[key]
+	delete(b.pending, key)
+	batch.flushed = true
+	members := append([]SecureChangeGroupMember{}, batch.members...)
+	b.mu.Unlock()
+
+	defer close(batch.done)
+	defer batch.cancel()
+
+	for len(members) > 0 {
+		added, removed := 0, 0
+		for _, member := range members {
+			if member.Status == "DELETED" {
+				removed++
+			} else {
+				added++
+			}
+		}
+		subject := fmt.Sprintf("Update Group %s: add %d, remove %d members", key.Group, added, removed)
+		debugLogOutput("group change batch", subject)
+
+		ticket := groupChangeTicket(subject, key.Group, key.ManagementID, members)
+		batch.ticketID, batch.err = submitTicket(batch.ctx, &b.client.SecureChange, ticket, batch.opts, "")
+		if batch.err == nil {
+			break
+		}
+
+		// SecureChange rejects the whole ticket for a member that is already in, or already out of, the group. Such
+		// members need no change, just as on the unbatched path, so they are dropped and the rest submitted again.
+		remaining := pendingMembers(members, key.Group, batch.err)
+		if len(remaining) == len(members) {
+			return
+		}
+		members = remaining
+		batch.err = nil
+	}
+	if batch.ticketID == 0 {
+		return
+	}
+
+	_, batch.err = waitForTicketOrCancel(batch.ctx, &b.client.SecureChange, batch.ticketID)
+}
+
+// pendingMembers returns the members whose change is not reported by err as already in effect
+func pendingMembers(members []SecureChangeGroupMember, group string, err error) []SecureChangeGroupMember {
+	remaining := []SecureChangeGroupMember{}
+	for _, member := range members {
+		if matched, _ := regexp.MatchString(memberIgnorePattern(&member, group), err.Error()); !matched {
+			remaining = append(remaining, member)
+		}
+	}
+	return remaining
+}
+
+// batchMemberIndex finds the same change to the same member in a batch, returning -1 when it is not queued
+func batchMemberIndex(batch *groupChangeBatch, member *SecureChangeGroupMember) int {
+	for i, queued := range batch.members {
+		if queued.Name == member.Name && queued.Status == member.Status {
+			return i
+		}
+	}
+	return -1
+}
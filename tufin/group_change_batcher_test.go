@@ -0,0 +1,67 @@
+package tufin
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+func TestPendingMembers(t *testing.T) {
+	member := func(name, status string) SecureChangeGroupMember {
+		return SecureChangeGroupMember{SecureChangeGroupMember: tufinclient.SecureChangeGroupMember{Name: name, Status: status}}
+	}
+	names := func(members []SecureChangeGroupMember) []string {
+		names := []string{}
+		for _, member := range members {
+			names = append(names, member.Status+" "+member.Name)
+		}
+		return names
+	}
+
+	cases := []struct {
+		name     string
+		members  []SecureChangeGroupMember
+		err      string
+		expected []string
+	}{
+		{
+			name:     "already absent removal leaves the real removal",
+			members:  []SecureChangeGroupMember{member("web-1", "DELETED"), member("web-2", "DELETED")},
+			err:      "object with name web-1 in group web-servers does not exist",
+			expected: []string{"DELETED web-2"},
+		},
+		{
+			name:     "already present addition leaves the removal",
+			members:  []SecureChangeGroupMember{member("web-1", "ADDED"), member("web-2", "DELETED")},
+			err:      "object with name web-1 in group web-servers already exists",
+			expected: []string{"DELETED web-2"},
+		},
+		{
+			name:     "addition is not dropped for a removal error",
+			members:  []SecureChangeGroupMember{member("web-1", "ADDED")},
+			err:      "object with name web-1 in group web-servers does not exist",
+			expected: []string{"ADDED web-1"},
+		},
+		{
+			name:     "other group",
+			members:  []SecureChangeGroupMember{member("web-1", "DELETED")},
+			err:      "object with name web-1 in group db-servers does not exist",
+			expected: []string{"DELETED web-1"},
+		},
+		{
+			name:     "unrelated error",
+			members:  []SecureChangeGroupMember{member("web-1", "DELETED"), member("web-2", "ADDED")},
+			err:      "Unauthorized request",
+			expected: []string{"DELETED web-1", "ADDED web-2"},
+		},
+	}
+
+	for _, c := range cases {
+		actual := names(pendingMembers(c.members, "web-servers", errors.New(c.err)))
+		if !reflect.DeepEqual(actual, c.expected) {
+			t.Errorf("%s: pendingMembers = %v, expected %v", c.name, actual, c.expected)
+		}
+	}
+}
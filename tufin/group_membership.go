@@ -0,0 +1,253 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// memberResolver builds the SecureChange member to submit for a single device, returning nil when it has no counterpart there
+type memberResolver func(deviceID string) (*SecureChangeGroupMember, error)
+
+// addressMemberResolver resolves a host, subnet or range. The member reuses the object called name (or named after
+// the address when name is empty) if the device has one, and otherwise asks SecureChange to create it with comment.
+// A reused object must hold the same address, and warn, when not nil, is told when its comment cannot be applied.
+func addressMemberResolver(client *tufinclient.TufinClient, addr *networkAddress, name string, comment string, warn func(string)) memberResolver {
+	if name == "" {
+		name = addr.Value
+	}
+	return func(deviceID string) (*SecureChangeGroupMember, error) {
+		existing, err := client.SecureTrack.GetDeviceNetworkObjectByName(name, deviceID, true)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			current, err := networkObjectAddress(&client.SecureTrack, existing)
+			if err != nil {
+				return nil, err
+			}
+			// Groups and other objects without an address of their own cannot be compared
+			if current != "" && current != addr.Value {
+				return nil, fmt.Errorf("Network object %s on device %s has address %s rather than %s", name, deviceID, current, addr.Value)
+			}
+			if comment != "" && existing.Comment != comment && warn != nil {
+				warn(fmt.Sprintf("Network object %s already exists on device %s, so it is reused without the configured comment.", name, deviceID))
+			}
+		}
+		member := SecureChangeGroupMember{
+			SecureChangeGroupMember: tufinclient.SecureChangeGroupMember{
+				Name:          name,
+				XsiType:       "groupMemberNetworkObjectDTO",
+				ObjectDetails: addr.ObjectDetails,
+				ObjectType:    addr.ObjectType,
+				Status:        "ADDED",
+			},
+		}
+		if existing == nil {
+			member.Type = addr.Type
+			member.ObjectUpdatedStatus = "NEW"
+			member.Comment = comment
+		} else {
+			member.Type = "Object"
+			member.ObjectUpdatedStatus = "EXISTING_NOT_EDITED"
+			member.ManagementID = existing.DeviceID
+		}
+		return &member, nil
+	}
+}
+
+// existingObjectResolver resolves a named network object, or one identified by uid, that must already exist on the device
+func existingObjectResolver(client *tufinclient.TufinClient, name string, uid string) memberResolver {
+	return func(deviceID string) (*SecureChangeGroupMember, error) {
+		var obj *tufinclient.SecureTrackNetworkObject
+		var err error
+		if uid != "" {
+			obj, err = getDeviceNetworkObjectByUID(&client.SecureTrack, uid, deviceID)
+		} else {
+			obj, err = client.SecureTrack.GetDeviceNetworkObjectByName(name, deviceID, true)
+		}
+		if err != nil || obj == nil {
+			return nil, err
+		}
+		return &SecureChangeGroupMember{
+			SecureChangeGroupMember: tufinclient.SecureChangeGroupMember{
+				Name:                obj.Name,
+				XsiType:             "groupMemberNetworkObjectDTO",
+				Type:                "Object",
+				ObjectDetails:       objectDetails(obj),
+				ObjectType:          objectType(obj),
+				ObjectUpdatedStatus: "EXISTING_NOT_EDITED",
+				ManagementID:        obj.DeviceID,
+				Status:              "ADDED",
+			},
+		}, nil
+	}
+}
+
+// addMemberToGroup adds the resolved member to every device group with the given name, waiting for each ticket when
+// wait is set. tickets holds the ticket opened for each device, leaving out devices where the member already was.
+func addMemberToGroup(ctx context.Context, meta *providerMeta, group string, resolve memberResolver, opts ticketOptions, wait bool) (added bool, tickets map[string]int64, err error) {
+	tickets = map[string]int64{}
+	objs, err := meta.Client.SecureTrack.GetNetworkObjectsByName(group)
+	if err != nil {
+		return added, tickets, err
+	}
+	// Avoid nil objs as this means the FW group does not exist
+	if objs == nil {
+		return added, tickets, nil
+	}
+	for _, obj := range *objs {
+		// DisplayName check accounts for incorrectly cased results coming back from exact_match object search
+		if obj.DisplayName != group {
+			continue
+		}
+		deviceID := strconv.FormatInt(obj.DeviceID, 10)
+		member, err := resolve(deviceID)
+		if err != nil {
+			return added, tickets, err
+		}
+		if member == nil {
+			return added, tickets, fmt.Errorf("Network object to add to group %s does not exist on device %s", group, deviceID)
+		}
+		if groupHasMember(&obj, member.Name) {
+			added = true
+			continue
+		}
+		ticketID, err := submitGroupMember(ctx, meta, member, group, deviceID, opts, wait)
+		if err != nil {
+			return added, tickets, err
+		}
+		if ticketID != 0 {
+			tickets[deviceID] = ticketID
+		}
+		added = true
+	}
+	return added, tickets, nil
+}
+
+// removeMemberFromGroup removes the resolved member from every device group with the given name, waiting for each
+// ticket when wait is set
+func removeMemberFromGroup(ctx context.Context, meta *providerMeta, group string, resolve memberResolver, opts ticketOptions, wait bool) (bool, error) {
+	objs, err := meta.Client.SecureTrack.GetNetworkObjectsByName(group)
+	removed := false
+	if err != nil {
+		return removed, err
+	}
+	// Avoid nil objs as this means the FW group does not exist
+	if objs == nil {
+		return removed, nil
+	}
+	for _, obj := range *objs {
+		// DisplayName check accounts for incorrectly cased results coming back from exact_match object search
+		if obj.DisplayName != group {
+			continue
+		}
+		deviceID := strconv.FormatInt(obj.DeviceID, 10)
+		member, err := resolve(deviceID)
+		if err != nil {
+			return removed, err
+		}
+		if member == nil || !groupHasMember(&obj, member.Name) {
+			continue
+		}
+		memberToDelete := SecureChangeGroupMember{
+			SecureChangeGroupMember: tufinclient.SecureChangeGroupMember{
+				XsiType:      "groupMemberNetworkObjectDTO",
+				Type:         "Object",
+				ManagementID: obj.DeviceID,
+				Status:       "DELETED",
+				Name:         member.Name,
+				ObjectType:   member.ObjectType,
+			},
+		}
+		_, err = submitGroupMember(ctx, meta, &memberToDelete, group, deviceID, opts, wait)
+		if err != nil {
+			return removed, err
+		}
+		removed = true
+	}
+	return removed, nil
+}
+
+// groupMembership reports, for each device holding a group with the given name, whether the resolved member is in
+// it. Group objects come from the provider's membership cache, so each group is only fetched once per run.
+func groupMembership(meta *providerMeta, group string, resolve memberResolver) (map[string]bool, error) {
+	objs, err := meta.Memberships.groupObjects(group)
+	if err != nil {
+		return nil, err
+	}
+	membership := map[string]bool{}
+	for _, obj := range objs {
+		// DisplayName check accounts for incorrectly cased results coming back from exact_match object search
+		if obj.DisplayName != group {
+			continue
+		}
+		deviceID := strconv.FormatInt(obj.DeviceID, 10)
+		member, err := resolve(deviceID)
+		if err != nil {
+			return nil, err
+		}
+		membership[deviceID] = member != nil && groupHasMember(&obj, member.Name)
+	}
+	return membership, nil
+}
+
+// submitGroupMember opens the ticket for a single member change, going through the batcher when batching is enabled.
+// Batched changes always wait for the shared ticket, while an unbatched ticket is only waited for when wait is set.
+// Either way a ticket still open when the apply is interrupted or times out is cancelled.
+func submitGroupMember(ctx context.Context, meta *providerMeta, member *SecureChangeGroupMember, group string, deviceID string, opts ticketOptions, wait bool) (int64, error) {
+	if meta.Batcher != nil {
+		return meta.Batcher.Submit(ctx, member, group, deviceID, opts)
+	}
+	var ticketID int64
+	var err error
+	if member.Status == "DELETED" {
+		ticketID, err = removeMemberFromDeviceGroup(ctx, &meta.Client.SecureChange, member, group, deviceID, opts)
+	} else {
+		ticketID, err = addMemberToDeviceGroup(ctx, &meta.Client.SecureChange, member, group, deviceID, opts)
+	}
+	if err != nil || ticketID == 0 || !wait {
+		return ticketID, err
+	}
+	_, err = waitForTicketOrCancel(ctx, &meta.Client.SecureChange, ticketID)
+	return ticketID, err
+}
+
+// groupHasMember reports whether a SecureTrack group already contains a member with the given name
+func groupHasMember(obj *tufinclient.SecureTrackNetworkObject, name string) bool {
+	for _, member := range obj.Member {
+		if member.DisplayName == name || member.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// objectType maps a SecureTrack network object onto the SecureChange object_type naming
+func objectType(obj *tufinclient.SecureTrackNetworkObject) string {
+	switch obj.XsiType {
+	case "hostNetworkObjectDTO":
+		return "Host"
+	case "subnetNetworkObjectDTO":
+		return "Network"
+	case "rangeNetworkObjectDTO":
+		return "Address Range"
+	case "networkObjectGroupDTO":
+		return "Group"
+	default:
+		return obj.Type
+	}
+}
+
+// objectDetails renders the address of a SecureTrack network object as SecureChange object_details
+func objectDetails(obj *tufinclient.SecureTrackNetworkObject) string {
+	if obj.IP == "" {
+		return ""
+	}
+	if obj.Netmask == "" {
+		return obj.IP
+	}
+	return obj.IP + "/" + obj.Netmask
+}
@@ -0,0 +1,98 @@
+package tufin
+
+import (
+	"sync"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// membershipCache shares SecureTrack lookups between the group member resources refreshed in a single run, so that
+// each device's latest revision and each group's objects are only fetched once however many members reference them
+type membershipCache struct {
+	client *tufinclient.TufinClient
+
+	// mu only guards the maps, while each entry has its own lock held during its fetch, so that lookups of
+	// different devices and groups run in parallel and lookups of the same one wait for a single fetch
+	mu        sync.Mutex
+	revisions map[string]*revisionEntry
+	groups    map[string]*groupEntry
+}
+
+// revisionEntry caches the latest revision of a single device
+type revisionEntry struct {
+	mu       sync.Mutex
+	fetched  bool
+	revision string
+}
+
+// groupEntry caches the objects of a single group name
+type groupEntry struct {
+	mu      sync.Mutex
+	fetched bool
+	objs    []tufinclient.SecureTrackNetworkObject
+}
+
+// newMembershipCache creates an empty cache for the given client
+func newMembershipCache(client *tufinclient.TufinClient) *membershipCache {
+	return &membershipCache{
+		client:    client,
+		revisions: map[string]*revisionEntry{},
+		groups:    map[string]*groupEntry{},
+	}
+}
+
+// latestRevision returns the latest revision of a device, or an empty string if the device no longer exists
+func (c *membershipCache) latestRevision(deviceID string) (string, error) {
+	c.mu.Lock()
+	entry, ok := c.revisions[deviceID]
+	if !ok {
+		entry = &revisionEntry{}
+		c.revisions[deviceID] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	// A failed fetch is not cached, so the next lookup tries again
+	if entry.fetched {
+		return entry.revision, nil
+	}
+	device, err := getDeviceByID(&c.client.SecureTrack, deviceID)
+	if err != nil {
+		return "", err
+	}
+	if device != nil {
+		entry.revision = device.LatestRevision
+	}
+	entry.fetched = true
+	return entry.revision, nil
+}
+
+// groupObjects returns the group objects with the given name across all devices
+func (c *membershipCache) groupObjects(group string) ([]tufinclient.SecureTrackNetworkObject, error) {
+	c.mu.Lock()
+	entry, ok := c.groups[group]
+	if !ok {
+		entry = &groupEntry{}
+		c.groups[group] = entry
+	}
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.fetched {
+		return entry.objs, nil
+	}
+	objs, err := c.client.SecureTrack.GetNetworkObjectsByName(group)
+	if err != nil {
+		return nil, err
+	}
+	entry.objs = []tufinclient.SecureTrackNetworkObject{}
+	if objs != nil {
+		entry.objs = *objs
+	}
+	entry.fetched = true
+	return entry.objs, nil
+}
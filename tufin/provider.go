@@ -2,6 +2,7 @@ package tufin
 
 import (
 	"context"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -13,6 +14,17 @@ type ManagementConfig struct {
 	Domain string
 }
 
+// providerMeta is passed to every resource and data source as its meta argument
+type providerMeta struct {
+	Client *tufinclient.TufinClient
+	// Batcher coalesces group member changes into shared tickets, and is nil unless batch_group_changes is set
+	Batcher *groupChangeBatcher
+	// TicketDefaults holds the provider's ticket templates and priority, which resources override per ticket
+	TicketDefaults ticketOptions
+	// Memberships caches device revisions and group objects while group members are refreshed
+	Memberships *membershipCache
+}
+
 // Provider -
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -42,11 +54,85 @@ func Provider() *schema.Provider {
 				Required: true,
 				DefaultFunc: schema.EnvDefaultFunc("TUFIN_ALLOW_INSECURE", nil),
 			},
+			"batch_group_changes": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TUFIN_BATCH_GROUP_CHANGES", false),
+				Description: "Coalesce member changes to the same group and device into a single ticket. Batches can only be as large as Terraform's -parallelism.",
+			},
+			"batch_window": &schema.Schema{
+				Type:        schema.TypeInt,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TUFIN_BATCH_WINDOW", 10),
+				Description: "Seconds to wait for further member changes before submitting a batched group change ticket.",
+			},
+			"ticket_subject_template": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TUFIN_TICKET_SUBJECT_TEMPLATE", ""),
+				ValidateFunc: validateTicketTemplate,
+				Description:  "Go template for ticket subjects. Has .Subject, .Justification, .Priority, .Requester, .Workspace, .RunID and an env function. .Workspace comes from TF_WORKSPACE or TFC_WORKSPACE_NAME and is empty for CLI workspaces selected without TF_WORKSPACE, and .RunID is only set in Terraform Cloud.",
+			},
+			"ticket_comment_template": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TUFIN_TICKET_COMMENT_TEMPLATE", ""),
+				ValidateFunc: validateTicketTemplate,
+				Description:  "Go template for the comment added to each ticket, with the same data as ticket_subject_template. Defaults to the justification.",
+			},
+			"ticket_priority": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("TUFIN_TICKET_PRIORITY", "Normal"),
+				ValidateFunc: validateTicketPriority,
+			},
+			"ticket_approval_reason": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TUFIN_TICKET_APPROVAL_REASON", "Pre-approved standard change, approved automatically by Terraform"),
+				Description: "Reason recorded when the provider approves the auto_advance_steps of a ticket.",
+			},
+			"ticket_change_comments": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TUFIN_TICKET_CHANGE_COMMENTS", false),
+				Description: "Comment the planned diff for the resource on each ticket the provider opens.",
+			},
+			"ticket_change_attachments": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("TUFIN_TICKET_CHANGE_ATTACHMENTS", false),
+				Description: "Attach a JSON summary of the planned changes to each ticket the provider opens.",
+			},
 		},
 		ResourcesMap:   map[string]*schema.Resource{
-			"tufin_group_member": resourceGroupMember(),
+			"tufin_group_member":      resourceGroupMember(),
+			"tufin_host_object":       resourceHostObject(),
+			"tufin_service_object":    resourceServiceObject(),
+			"tufin_service_group":     resourceServiceGroup(),
+			"tufin_access_request":    resourceAccessRequest(),
+			"tufin_ticket":            resourceTicket(),
+			"tufin_rule_modification": resourceRuleModification(),
+			"tufin_rule_decommission": resourceRuleDecommission(),
+			"tufin_zone":              resourceZone(),
+			"tufin_zone_entry":        resourceZoneEntry(),
+			"tufin_usp_matrix":        resourceUSPMatrix(),
+			"tufin_usp_exception":     resourceUSPException(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"tufin_ticket":           dataSourceTicket(),
+			"tufin_tickets":          dataSourceTickets(),
+			"tufin_risk_analysis":    dataSourceRiskAnalysis(),
+			"tufin_topology_path":    dataSourceTopologyPath(),
+			"tufin_rules":            dataSourceRules(),
+			"tufin_policy_query":     dataSourcePolicyQuery(),
+			"tufin_unused_rules":     dataSourceUnusedRules(),
+			"tufin_shadowed_rules":   dataSourceShadowedRules(),
+			"tufin_device_revisions": dataSourceDeviceRevisions(),
+			"tufin_revision_diff":    dataSourceRevisionDiff(),
+			"tufin_zones":            dataSourceZones(),
+			"tufin_usp_violations":   dataSourceUSPViolations(),
 		},
-		DataSourcesMap: map[string]*schema.Resource{},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
@@ -65,5 +151,30 @@ func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}
 	)
 	debugLogOutput("configuration", "client connection created")
 
-	return client, diags
+	subjectTemplate, err := parseTicketTemplate("ticket_subject_template", d.Get("ticket_subject_template").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	commentTemplate, err := parseTicketTemplate("ticket_comment_template", d.Get("ticket_comment_template").(string))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+
+	meta := &providerMeta{
+		Client:      client,
+		Memberships: newMembershipCache(client),
+		TicketDefaults: ticketOptions{
+			SubjectTemplate:   subjectTemplate,
+			CommentTemplate:   commentTemplate,
+			Priority:          d.Get("ticket_priority").(string),
+			ApprovalReason:    d.Get("ticket_approval_reason").(string),
+			ChangeComments:    d.Get("ticket_change_comments").(bool),
+			ChangeAttachments: d.Get("ticket_change_attachments").(bool),
+		},
+	}
+	if d.Get("batch_group_changes").(bool) {
+		meta.Batcher = newGroupChangeBatcher(client, time.Duration(d.Get("batch_window").(int))*time.Second)
+	}
+
+	return meta, diags
 }
@@ -0,0 +1,242 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAccessRequest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAccessRequestCreate,
+		ReadContext:   resourceAccessRequestRead,
+		UpdateContext: resourceAccessRequestUpdate,
+		DeleteContext: resourceAccessRequestDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"sources": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"destinations": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"services": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"action": &schema.Schema{
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				Default:      "Accept",
+				ValidateFunc: validateRuleAction,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"targets": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "Access Request Workflow",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_step": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// validateRuleAction is a schema ValidateFunc for the action an access request asks for
+func validateRuleAction(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if v != "Accept" && v != "Drop" {
+		errs = append(errs, fmt.Errorf("%q must be Accept or Drop.", key))
+	}
+	return
+}
+
+// accessRequestTicket builds an access request ticket for the resource's flow with the given action
+func accessRequestTicket(d *schema.ResourceData, subject string, action string) *SecureChangeTicket {
+	request := buildAccessRequest(
+		"AR1",
+		action,
+		d.Get("comment").(string),
+		d.Get("sources").([]interface{}),
+		d.Get("destinations").([]interface{}),
+		d.Get("services").([]interface{}),
+		d.Get("targets").([]interface{}),
+	)
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	return singleStepTicket(subject, workflow, "Submit access request",
+		SecureChangeAccessRequestField{
+			XsiType:       "multi_access_request",
+			Name:          "Required Access",
+			AccessRequest: []SecureChangeAccessRequest{request},
+		},
+	)
+}
+
+func resourceAccessRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	ticket := accessRequestTicket(d, d.Get("subject").(string), d.Get("action").(string))
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_access_request", "create", "subject", "sources", "destinations", "services", "action", "comment", "targets")
+	ticketID, err := submitTicket(ctx, &client.SecureChange, ticket, opts, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("access request creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId(strconv.FormatInt(ticketID, 10))
+
+	if d.Get("wait_for_completion").(bool) {
+		cancelled, err := waitForTicketOrCancel(ctx, &client.SecureChange, ticketID)
+		if cancelled {
+			d.SetId("")
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceAccessRequestRead(ctx, d, m)...)
+}
+
+func resourceAccessRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	ticketID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ticket, err := secureChange(&client.SecureChange).GetTicket(ticketID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ticket == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("status", ticket.Status)
+	if ticket.CurrentStep != nil {
+		d.Set("current_step", ticket.CurrentStep.Name)
+	} else {
+		d.Set("current_step", "")
+	}
+
+	return diags
+}
+
+func resourceAccessRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only wait_for_completion and the ticket settings can change in place, and neither affects the open ticket
+	return resourceAccessRequestRead(ctx, d, m)
+}
+
+func resourceAccessRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	requestID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	request, err := secureChange(&client.SecureChange).GetTicket(requestID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	status := d.Get("status").(string)
+	if request != nil {
+		status = request.Status
+	}
+
+	// A request still in flight is cancelled rather than left to open access nobody wants any more
+	if finished, _ := ticketFinished(status); !finished && request != nil {
+		if _, err := cancelOpenTicket(&client.SecureChange, requestID, "Cancelled by Terraform: the access request was destroyed before it was implemented."); err != nil {
+			return diag.FromErr(err)
+		}
+		d.SetId("")
+		return diags
+	}
+
+	// A request that was rejected or cancelled never opened access, so there is nothing to remove
+	if finished, implemented := ticketFinished(status); finished && !implemented {
+		d.SetId("")
+		return diags
+	}
+
+	ticket := accessRequestTicket(d, "Remove access: "+d.Get("subject").(string), "Remove")
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_access_request", "delete", "subject", "sources", "destinations", "services", "action", "comment", "targets")
+	ticketID, err := submitTicket(ctx, &client.SecureChange, ticket, opts, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("access request deletion", "opened removal ticket "+strconv.FormatInt(ticketID, 10))
+
+	if d.Get("wait_for_completion").(bool) {
+		if _, err := waitForTicketOrCancel(ctx, &client.SecureChange, ticketID); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return append(diags, opts.Warnings.Diagnostics()...)
+}
@@ -5,6 +5,9 @@ import (
   "fmt"
   "os"
   "regexp"
+  "sort"
+  "strconv"
+  "time"
 
   "github.com/hashicorp/go-uuid"
   "github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -16,9 +19,15 @@ func resourceGroupMember() *schema.Resource {
   return &schema.Resource{
     CreateContext: resourceGroupMemberCreate,
     ReadContext:   resourceGroupMemberRead,
-    //UpdateContext: resourceGroupMemberUpdate,
+    UpdateContext: resourceGroupMemberUpdate,
     DeleteContext: resourceGroupMemberDelete,
-    Schema: map[string]*schema.Schema{
+    // Batched changes, and others with wait_for_completion, wait for the ticket's outcome, which may take a human approval
+    Timeouts: &schema.ResourceTimeout{
+      Create: schema.DefaultTimeout(60 * time.Minute),
+      Update: schema.DefaultTimeout(60 * time.Minute),
+      Delete: schema.DefaultTimeout(60 * time.Minute),
+    },
+    Schema: ticketOptionsSchema(map[string]*schema.Schema{
       "group_name": &schema.Schema{
         Type:     schema.TypeString,
         ForceNew: true,
@@ -32,107 +41,269 @@ func resourceGroupMember() *schema.Resource {
         },
       },
       "ip_address": &schema.Schema{
-        Type:     schema.TypeString,
-        ForceNew: true,
-        Required: true,
-        ValidateFunc: func(val interface{}, key string) (warns[]string, errs []error) {
-          v := val.(string)
-          if match, _ := regexp.MatchString("[0-9]+\\.[0-9]+\\.[0-9]+\\.[0-9]+", v); ! match {
-            errs = append(errs, fmt.Errorf("%q includes invalid characters. May contain [uppercase letters, underscores].", key))
-          }
-          return
+        Type:          schema.TypeString,
+        ForceNew:      true,
+        Optional:      true,
+        AtLeastOneOf:  []string{"ip_address", "object_name", "object_uid"},
+        ConflictsWith: []string{"object_uid"},
+        ValidateFunc:  validateNetworkAddress,
+      },
+      "object_name": &schema.Schema{
+        Type:          schema.TypeString,
+        ForceNew:      true,
+        Optional:      true,
+        AtLeastOneOf:  []string{"ip_address", "object_name", "object_uid"},
+        ConflictsWith: []string{"object_uid"},
+      },
+      "object_uid": &schema.Schema{
+        Type:          schema.TypeString,
+        ForceNew:      true,
+        Optional:      true,
+        AtLeastOneOf:  []string{"ip_address", "object_name", "object_uid"},
+        ConflictsWith: []string{"ip_address", "object_name"},
+      },
+      "comment": &schema.Schema{
+        Type:         schema.TypeString,
+        ForceNew:     true,
+        Optional:     true,
+        RequiredWith: []string{"ip_address"},
+      },
+      "wait_for_completion": &schema.Schema{
+        Type:        schema.TypeBool,
+        Optional:    true,
+        Default:     false,
+        Description: "Wait for each ticket to be implemented, cancelling it if the apply is interrupted or times out. Batched changes always wait.",
+      },
+      "record_revision": &schema.Schema{
+        Type:        schema.TypeBool,
+        Optional:    true,
+        Default:     false,
+        Description: "Record the SecureTrack revision of each device in which the membership change landed.",
+      },
+      "ticket_ids": &schema.Schema{
+        Type:        schema.TypeMap,
+        Computed:    true,
+        Description: "SecureChange ticket that added the member on each device.",
+        Elem: &schema.Schema{
+          Type: schema.TypeString,
         },
       },
-    },
+      "revisions": &schema.Schema{
+        Type:     schema.TypeMap,
+        Computed: true,
+        Elem: &schema.Schema{
+          Type: schema.TypeString,
+        },
+      },
+      "verified_revisions": &schema.Schema{
+        Type:        schema.TypeMap,
+        Computed:    true,
+        Description: "Latest revision of each device holding the group when the membership was last checked.",
+        Elem: &schema.Schema{
+          Type: schema.TypeString,
+        },
+      },
+      "member_devices": &schema.Schema{
+        Type:        schema.TypeList,
+        Computed:    true,
+        Description: "Devices whose group held the member when the membership was last checked.",
+        Elem: &schema.Schema{
+          Type: schema.TypeString,
+        },
+      },
+    }, false),
     SchemaVersion: 1,
   }
 }
 
+// groupMemberResolver picks how the member is resolved on each device from whichever arguments were configured.
+// An object_name on its own must already exist, while alongside ip_address it names the object to create.
+// Warnings about the configured member, such as a comment that cannot be applied, are added to diags when not nil.
+func groupMemberResolver(client *tufinclient.TufinClient, ip_address string, object_name string, object_uid string, comment string, diags *diag.Diagnostics) (memberResolver, error) {
+  if ip_address == "" {
+    return existingObjectResolver(client, object_name, object_uid), nil
+  }
+  addr, err := parseNetworkAddress(ip_address)
+  if err != nil {
+    return nil, err
+  }
+  var warn func(string)
+  if diags != nil {
+    warn = func(detail string) {
+      *diags = append(*diags, diag.Diagnostic{
+        Severity: diag.Warning,
+        Summary:  "Group member comment not applied",
+        Detail:   detail,
+      })
+    }
+  }
+  return addressMemberResolver(client, addr, object_name, comment, warn), nil
+}
+
+// groupMemberTicketIDs converts the tickets opened per device into the ticket_ids attribute
+func groupMemberTicketIDs(tickets map[string]int64) map[string]interface{} {
+  ids := map[string]interface{}{}
+  for deviceID, ticketID := range tickets {
+    ids[deviceID] = strconv.FormatInt(ticketID, 10)
+  }
+  return ids
+}
+
+// groupMemberDescription names the configured member in error messages
+func groupMemberDescription(ip_address string, object_name string, object_uid string) string {
+  switch {
+  case ip_address != "":
+    return "address " + ip_address
+  case object_name != "":
+    return "object " + object_name
+  default:
+    return "object with uid " + object_uid
+  }
+}
+
 func resourceGroupMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
   // Warning or errors can be collected in a slice type
   var diags diag.Diagnostics
 
   group_name := d.Get("group_name").(string)
   ip_address := d.Get("ip_address").(string)
+  object_name := d.Get("object_name").(string)
+  object_uid := d.Get("object_uid").(string)
+  comment := d.Get("comment").(string)
 
-  client := m.(*tufinclient.TufinClient)
+  meta := m.(*providerMeta)
+  client := meta.Client
+
+  resolve, err := groupMemberResolver(client, ip_address, object_name, object_uid, comment, &diags)
+  if err != nil {
+    return diag.FromErr(err)
+  }
 
   debugLogOutput("create", "beginning creation reconcilliation")
 
-  added, err := client.AddIPToGroup(ip_address, group_name)
+  opts := meta.ticketOptions(d).withChanges(d, "tufin_group_member", "create", "group_name", "ip_address", "object_name", "object_uid", "comment")
+  added, tickets, err := addMemberToGroup(ctx, meta, group_name, resolve, opts, d.Get("wait_for_completion").(bool))
   if err != nil {
-    diag.FromErr(err)
+    return diag.FromErr(err)
   }
 
   debugLogOutput("create", "completed creation call")
 
   if added == false {
-    diag.FromErr(fmt.Errorf("Group %s does not exist or IP %s is not a viable member.", group_name, ip_address))
+    return diag.FromErr(fmt.Errorf("Group %s does not exist or %s is not a viable member.", group_name, groupMemberDescription(ip_address, object_name, object_uid)))
   } else {
-    debugLogOutput("group membership creation", "added IP address to group membership")
+    debugLogOutput("group membership creation", "added member to group membership")
   }
 
   newUuid, _ := uuid.GenerateUUID()
   d.SetId(newUuid)
+  d.Set("ticket_ids", groupMemberTicketIDs(tickets))
 
-  return diags
+  return append(diags, opts.Warnings.Diagnostics()...)
 }
 
 func resourceGroupMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
   // Warning or errors can be collected in a slice type
   var diags diag.Diagnostics
 
-  return diags
-}
-
-func resourceGroupMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-  var diags diag.Diagnostics
-
-  new_group := d.Get("group_name").(string)
-  new_ip := d.Get("ip_address").(string)
-  var old_group string
-  var old_ip string
-
-  client := m.(*tufinclient.TufinClient)
-
-  if d.HasChange("ip_address") {
-    // Get change returns old, new as interfaces so we need to specifically cast to string
-    old_ip_interface, _ := d.GetChange("ip_address")
-    old_ip = old_ip_interface.(string)
-  } else {
-    old_ip = new_ip
+  meta := m.(*providerMeta)
+
+  recordRevision := d.Get("record_revision").(bool)
+  revisions := d.Get("revisions").(map[string]interface{})
+  tickets := d.Get("ticket_ids").(map[string]interface{})
+
+  // Membership only changes with a new policy revision, so while no device holding the group has installed one since
+  // the last refresh, the membership checked then still stands and no group needs to be fetched. A revision still
+  // to be recorded needs the full refresh, as SecureTrack may link the ticket to its revision some time later.
+  verified := d.Get("verified_revisions").(map[string]interface{})
+  pending := false
+  for deviceID := range tickets {
+    if _, recorded := revisions[deviceID]; recordRevision && !recorded {
+      pending = true
+    }
   }
-
-  if d.HasChange("group_name") {
-    // Get change returns old, new as interfaces so we need to specifically cast to string
-    group_name_interface, _ := d.GetChange("group_name")
-    old_group = group_name_interface.(string)
-  } else {
-    old_group = new_group
+  if len(verified) > 0 && !pending {
+    unchanged := true
+    for deviceID, revision := range verified {
+      latest, err := meta.Memberships.latestRevision(deviceID)
+      if err != nil {
+        return diag.FromErr(err)
+      }
+      if latest != revision.(string) {
+        unchanged = false
+        break
+      }
+    }
+    if unchanged {
+      return diags
+    }
   }
 
-  removed, err := client.RemoveIPFromGroup(old_ip, old_group)
+  resolve, err := groupMemberResolver(meta.Client, d.Get("ip_address").(string), d.Get("object_name").(string), d.Get("object_uid").(string), d.Get("comment").(string), nil)
   if err != nil {
-    diag.FromErr(err)
+    return diag.FromErr(err)
   }
-
-  if removed == false {
-    diag.FromErr(fmt.Errorf("Failed to remove %s IP from %s Group ", old_ip, old_group))
-  } else {
-    debugLogOutput("group membership update deletion", "removed IP address from group membership")
+  membership, err := groupMembership(meta, d.Get("group_name").(string), resolve)
+  if err != nil {
+    return diag.FromErr(err)
   }
 
-  added, err := client.AddIPToGroup(new_ip, new_group)
-  if err != nil {
-    diag.FromErr(err)
+  // A member confirmed earlier that has since left a group was removed outside Terraform, so it is dropped from
+  // state to be added again. Until the first confirmation the ticket may simply not be implemented yet.
+  for _, deviceID := range d.Get("member_devices").([]interface{}) {
+    if !membership[deviceID.(string)] {
+      d.SetId("")
+      return append(diags, diag.Diagnostic{
+        Severity: diag.Warning,
+        Summary:  "Group member removed outside Terraform",
+        Detail:   fmt.Sprintf("The member is no longer in group %s on device %s, so it will be added again.", d.Get("group_name").(string), deviceID.(string)),
+      })
+    }
   }
 
-  if added == false {
-    diag.FromErr(fmt.Errorf("Group %s does not exist or IP %s is not a viable member.", new_group, new_ip))
-  } else {
-    debugLogOutput("group membership update creation", "added IP address to group membership")
+  verified = map[string]interface{}{}
+  members := []interface{}{}
+  for deviceID, member := range membership {
+    latest, err := meta.Memberships.latestRevision(deviceID)
+    if err != nil {
+      return diag.FromErr(err)
+    }
+    verified[deviceID] = latest
+    if !member {
+      continue
+    }
+    members = append(members, deviceID)
+    // The change landed in the revision listing its ticket, which SecureTrack may only link once the revision is
+    // retrieved, so the lookup is repeated on each refresh until it is found
+    ticketID, ok := tickets[deviceID]
+    if _, recorded := revisions[deviceID]; !recordRevision || recorded || !ok {
+      continue
+    }
+    mgmtID, err := strconv.ParseInt(deviceID, 10, 64)
+    if err != nil {
+      return diag.FromErr(err)
+    }
+    revision, err := ticketRevision(&meta.Client.SecureTrack, mgmtID, ticketID.(string))
+    if err != nil {
+      return diag.FromErr(err)
+    }
+    if revision != "" {
+      revisions[deviceID] = revision
+    }
   }
+  sort.Slice(members, func(i, j int) bool { return members[i].(string) < members[j].(string) })
+  d.Set("member_devices", members)
+  d.Set("verified_revisions", verified)
+  d.Set("revisions", revisions)
+
+  return diags
+}
+
+func resourceGroupMemberUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+  var diags diag.Diagnostics
 
+  // Every member argument forces a replacement, so only the ticket settings change in place, and they only apply
+  // to future tickets
   return diags
 }
 
@@ -142,23 +313,34 @@ func resourceGroupMemberDelete(ctx context.Context, d *schema.ResourceData, m in
 
   group_name := d.Get("group_name").(string)
   ip_address := d.Get("ip_address").(string)
+  object_name := d.Get("object_name").(string)
+  object_uid := d.Get("object_uid").(string)
+  comment := d.Get("comment").(string)
 
-  client := m.(*tufinclient.TufinClient)
+  meta := m.(*providerMeta)
+  client := meta.Client
 
-  removed, err := client.RemoveIPFromGroup(ip_address, group_name)
+  resolve, err := groupMemberResolver(client, ip_address, object_name, object_uid, comment, nil)
   if err != nil {
-    diag.FromErr(err)
+    return diag.FromErr(err)
+  }
+
+  opts := meta.ticketOptions(d).withChanges(d, "tufin_group_member", "delete", "group_name", "ip_address", "object_name", "object_uid", "comment")
+  removed, err := removeMemberFromGroup(ctx, meta, group_name, resolve, opts, d.Get("wait_for_completion").(bool))
+  if err != nil {
+    return diag.FromErr(err)
   }
 
   if removed == false {
-    diag.FromErr(fmt.Errorf("Failed to remove %s IP from %s Group ", ip_address, group_name))
+    // Nothing to remove means the member is already gone, which is the state we want
+    debugLogOutput("group membership deletion", "member was not part of the group")
   } else {
-    debugLogOutput("group membership deletion", "removed IP address from group membership")
+    debugLogOutput("group membership deletion", "removed member from group membership")
   }
 
   d.SetId("")
 
-  return diags
+  return append(diags, opts.Warnings.Diagnostics()...)
 }
 
 func debugLogOutput(id string, output string) {
@@ -0,0 +1,244 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceHostObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceHostObjectCreate,
+		ReadContext:   resourceHostObjectRead,
+		UpdateContext: resourceHostObjectUpdate,
+		DeleteContext: resourceHostObjectDelete,
+		// Waiting for the ticket may take a human approval
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"address": &schema.Schema{
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateNetworkAddress,
+				DiffSuppressFunc: suppressEquivalentAddress,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Network Object Change Template",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the ticket to be implemented, cancelling it if the apply is interrupted or times out.",
+			},
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"implemented": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// hostObjectChange builds the object change for a tufin_host_object from its configured arguments
+func hostObjectChange(d *schema.ResourceData, action string) (SecureChangeNetworkObjectChange, error) {
+	addr, err := parseNetworkAddress(d.Get("address").(string))
+	if err != nil {
+		return SecureChangeNetworkObjectChange{}, err
+	}
+	return SecureChangeNetworkObjectChange{
+		ChangeAction:  action,
+		Name:          d.Get("name").(string),
+		ObjectType:    addr.ObjectType,
+		ObjectDetails: addr.ObjectDetails,
+		Comment:       d.Get("comment").(string),
+	}, nil
+}
+
+func resourceHostObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	change, err := hostObjectChange(d, "CREATE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_host_object", "create", "name", "address", "comment", "devices")
+	ticketID, err := changeNetworkObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("host object creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return networkObjectOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceHostObjectRead(ctx, d, m)...)
+}
+
+func resourceHostObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	name := d.Get("name").(string)
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found := 0
+	for _, mgmtID := range mgmtIDs {
+		obj, err := client.SecureTrack.GetDeviceNetworkObjectByName(name, strconv.FormatInt(mgmtID, 10), true)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if obj == nil {
+			continue
+		}
+		found++
+		// The first device holding the object is treated as authoritative for drift detection
+		if found == 1 {
+			addr, err := networkObjectAddress(&client.SecureTrack, obj)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			if addr != "" {
+				d.Set("address", addr)
+			}
+			d.Set("comment", obj.Comment)
+		}
+	}
+
+	// Objects only appear in SecureTrack once their ticket is implemented, so a missing object is only removed from
+	// state once it had been implemented and has since disappeared from every device
+	if found == 0 && d.Get("implemented").(bool) {
+		d.SetId("")
+		return diags
+	}
+	d.Set("implemented", found == len(mgmtIDs))
+
+	return diags
+}
+
+func resourceHostObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Ticket settings only apply to future tickets, so changing nothing else needs no ticket
+	if !d.HasChanges("address", "comment") {
+		return resourceHostObjectRead(ctx, d, m)
+	}
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	change, err := hostObjectChange(d, "UPDATE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_host_object", "update", "name", "address", "comment", "devices")
+	ticketID, err := changeNetworkObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("host object update", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return networkObjectOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceHostObjectRead(ctx, d, m)...)
+}
+
+func resourceHostObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	done, err := cancelPendingObjectTicket(&client.SecureChange, d, "host object "+d.Get("name").(string), func() (bool, error) {
+		return networkObjectOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if done {
+		d.SetId("")
+		return diags
+	}
+
+	change, err := hostObjectChange(d, "DELETE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_host_object", "delete", "name", "address", "comment", "devices")
+	ticketID, err := changeNetworkObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("host object deletion", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId("")
+
+	return append(diags, opts.Warnings.Diagnostics()...)
+}
@@ -0,0 +1,276 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+func resourceRuleDecommission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceRuleDecommissionCreate,
+		ReadContext:   resourceRuleDecommissionRead,
+		UpdateContext: resourceRuleDecommissionUpdate,
+		DeleteContext: resourceRuleDecommissionDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rule_uids": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"action": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "disable",
+				Description: "Whether to disable or remove the rules. Disabling fails for a UID found on none of the devices, " +
+					"while removing treats it as already removed.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					v := val.(string)
+					if v != "disable" && v != "remove" {
+						errs = append(errs, fmt.Errorf("%q must be disable or remove.", key))
+					}
+					return
+				},
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+				Default:  "Rule Decommission Workflow",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"implemented": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"active_rule_uids": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Rules SecureTrack still reports as enabled, or present when removing them.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// decommissionState finds the resource's rules on its devices, returning the decommission request for those still
+// active along with their UIDs, and the UIDs found on none of the devices. Rules count as active while enabled, or
+// while present at all when removing them, in which case a rule found nowhere is already removed rather than missing.
+func decommissionState(c *tufinclient.SecureTrackClient, d *schema.ResourceData) ([]SecureChangeDecommissionDevice, []string, []string, error) {
+	deviceIDs, err := resolveDeviceIDs(c, d.Get("devices").([]interface{}))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	remove := d.Get("action").(string) == "remove"
+
+	found := map[string]bool{}
+	devices := []SecureChangeDecommissionDevice{}
+	active := []string{}
+	for _, deviceID := range deviceIDs {
+		bindings := map[string][]int64{}
+		order := []string{}
+		for _, uid := range d.Get("rule_uids").([]interface{}) {
+			rule, err := secureTrack(c).GetDeviceRuleByUID(deviceID, uid.(string))
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if rule == nil {
+				continue
+			}
+			found[uid.(string)] = true
+			if rule.Disabled && !remove {
+				continue
+			}
+			if _, ok := bindings[rule.Binding.BindingUID]; !ok {
+				order = append(order, rule.Binding.BindingUID)
+			}
+			bindings[rule.Binding.BindingUID] = append(bindings[rule.Binding.BindingUID], rule.ID)
+			active = append(active, rule.UID)
+		}
+		if len(order) == 0 {
+			continue
+		}
+		device := SecureChangeDecommissionDevice{ManagementID: deviceID}
+		for _, binding := range order {
+			device.Bindings.Binding = append(device.Bindings.Binding, SecureChangeDecommissionBinding{
+				BindingUID: binding,
+				RuleIDs:    SecureChangeDecommissionRuleIDs{ID: bindings[binding]},
+			})
+		}
+		devices = append(devices, device)
+	}
+
+	missing := []string{}
+	for _, uid := range d.Get("rule_uids").([]interface{}) {
+		if !found[uid.(string)] && !remove {
+			missing = append(missing, uid.(string))
+		}
+	}
+	return devices, active, missing, nil
+}
+
+func resourceRuleDecommissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	devices, active, missing, err := decommissionState(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// A mistyped UID would otherwise leave its rule running while the resource reports success. Removal cannot tell
+	// a mistyped UID from a rule that is already gone, so it treats both as done.
+	if len(missing) > 0 {
+		return diag.Errorf("Rules %s do not exist on any of the devices", strings.Join(missing, ", "))
+	}
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+
+	// Rules that are already disabled or gone need no ticket
+	if len(active) == 0 {
+		return resourceRuleDecommissionRead(ctx, d, m)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	action := strings.ToUpper(d.Get("action").(string))
+	subject := fmt.Sprintf("Decommission (%s) %d rules", strings.ToLower(action), len(active))
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_rule_decommission", "create", "devices", "rule_uids", "action")
+	ticketID, err := decommissionRules(ctx, &client.SecureChange, workflow, subject, action, devices, opts)
+	if err != nil {
+		d.SetId("")
+		return diag.FromErr(err)
+	}
+	debugLogOutput("rule decommission creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+	d.Set("ticket_id", ticketID)
+
+	if d.Get("wait_for_completion").(bool) {
+		cancelled, err := waitForTicketOrCancel(ctx, &client.SecureChange, ticketID)
+		if cancelled {
+			d.SetId("")
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitForDecommission(ctx, &client.SecureTrack, d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceRuleDecommissionRead(ctx, d, m)...)
+}
+
+// waitForDecommission polls SecureTrack until none of the rules are active, since a newly implemented ticket only
+// shows up once SecureTrack has retrieved the device's next revision
+func waitForDecommission(ctx context.Context, c *tufinclient.SecureTrackClient, d *schema.ResourceData) error {
+	for {
+		_, active, _, err := decommissionState(c, d)
+		if err != nil {
+			return err
+		}
+		if len(active) == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Ticket %d was implemented but SecureTrack still reports rules %s as active: %s", d.Get("ticket_id").(int), strings.Join(active, ", "), ctx.Err())
+		case <-time.After(ticketPollInterval):
+		}
+	}
+}
+
+func resourceRuleDecommissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	if ticketID := int64(d.Get("ticket_id").(int)); ticketID != 0 {
+		ticket, err := secureChange(&client.SecureChange).GetTicket(ticketID)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if ticket != nil {
+			d.Set("status", ticket.Status)
+		}
+	}
+
+	_, active, _, err := decommissionState(&client.SecureTrack, d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("active_rule_uids", active)
+
+	// Once decommissioned, a rule that comes back has been re-enabled or re-created outside Terraform, so the
+	// resource is dropped from state to plan a fresh decommission
+	if d.Get("implemented").(bool) && len(active) > 0 {
+		d.SetId("")
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Decommissioned rules are active again",
+			Detail:   fmt.Sprintf("Rules %s are active again, so a new decommission will be planned.", strings.Join(active, ", ")),
+		})
+	}
+	d.Set("implemented", len(active) == 0)
+
+	return diags
+}
+
+func resourceRuleDecommissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only wait_for_completion and the ticket settings can change in place, and neither affects the open ticket
+	return resourceRuleDecommissionRead(ctx, d, m)
+}
+
+func resourceRuleDecommissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	// A decommission that has not happened yet is cancelled, while one that has cannot be undone and is only forgotten
+	if _, err := cancelPendingObjectTicket(&client.SecureChange, d, "rule decommission", func() (bool, error) {
+		return true, nil
+	}); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
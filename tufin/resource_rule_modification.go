@@ -0,0 +1,322 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ruleModificationSides pairs each rule side with the arguments adding to and removing from it
+var ruleModificationSides = []struct {
+	Name   string
+	Add    string
+	Remove string
+}{
+	{"sources", "add_sources", "remove_sources"},
+	{"destinations", "add_destinations", "remove_destinations"},
+	{"services", "add_services", "remove_services"},
+}
+
+func resourceRuleModification() *schema.Resource {
+	modificationList := func() *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeList,
+			ForceNew:     true,
+			Optional:     true,
+			AtLeastOneOf: []string{"add_sources", "remove_sources", "add_destinations", "remove_destinations", "add_services", "remove_services"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	currentList := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+	}
+	return &schema.Resource{
+		CreateContext: resourceRuleModificationCreate,
+		ReadContext:   resourceRuleModificationRead,
+		UpdateContext: resourceRuleModificationUpdate,
+		DeleteContext: resourceRuleModificationDelete,
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"device": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"rule_uid": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"add_sources":         modificationList(),
+			"remove_sources":      modificationList(),
+			"add_destinations":    modificationList(),
+			"remove_destinations": modificationList(),
+			"add_services":        modificationList(),
+			"remove_services":     modificationList(),
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Modify Rule Workflow",
+			},
+			"revert_on_destroy": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Open a ticket undoing the modification when the resource is destroyed.",
+			},
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"implemented": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"rule_number": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"sources":      currentList(),
+			"destinations": currentList(),
+			"services":     currentList(),
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// ruleModification builds the modification for the resource's rule, swapping what is added and removed when revert
+// is set so the ticket undoes the change
+func ruleModification(d *schema.ResourceData, m interface{}, revert bool) (*SecureChangeRuleModification, error) {
+	client := m.(*providerMeta).Client
+
+	deviceIDs, err := resolveDeviceIDs(&client.SecureTrack, []interface{}{d.Get("device").(string)})
+	if err != nil {
+		return nil, err
+	}
+	deviceID := deviceIDs[0]
+
+	rule, err := secureTrack(&client.SecureTrack).GetDeviceRuleByUID(deviceID, d.Get("rule_uid").(string))
+	if err != nil {
+		return nil, err
+	}
+	if rule == nil {
+		return nil, fmt.Errorf("Rule %s does not exist on device %s", d.Get("rule_uid").(string), d.Get("device").(string))
+	}
+
+	modification := SecureChangeRuleModification{
+		DeviceID: deviceID,
+		RuleKey: SecureChangeRuleKey{
+			DeviceID:   deviceID,
+			BindingUID: rule.Binding.BindingUID,
+			RuleUID:    rule.UID,
+		},
+	}
+	objects := map[string][]SecureTrackRuleObject{
+		"sources":      rule.SrcNetwork,
+		"destinations": rule.DstNetwork,
+		"services":     rule.DstService,
+	}
+	for _, side := range ruleModificationSides {
+		add, remove := d.Get(side.Add).([]interface{}), d.Get(side.Remove).([]interface{})
+		if revert {
+			add, remove = remove, add
+		}
+		if len(add) == 0 && len(remove) == 0 {
+			continue
+		}
+
+		convert := func(values []interface{}) ([]SecureChangeModificationObject, error) {
+			return ruleNetworkObjects(&client.SecureTrack, deviceID, values)
+		}
+		if side.Name == "services" {
+			convert = func(values []interface{}) ([]SecureChangeModificationObject, error) {
+				return ruleServiceObjects(&client.SecureTrack, deviceID, values)
+			}
+		}
+
+		modifications := &SecureChangeObjectModifications{}
+		if len(add) > 0 {
+			objs, err := convert(add)
+			if err != nil {
+				return nil, err
+			}
+			modifications.AddObjects = ruleModificationObjects(side.Name, objs)
+		}
+		if len(remove) > 0 {
+			// Removals must name the object the rule already references, which may differ from what an address
+			// argument would create
+			objs := make([]SecureChangeModificationObject, 0, len(remove))
+			for _, v := range remove {
+				existing := ruleObject(objects[side.Name], v.(string))
+				if existing == nil {
+					// Reverting leaves alone what someone else has already taken off the rule
+					if revert {
+						continue
+					}
+					return nil, fmt.Errorf("Rule %s has no %s matching %s to remove", rule.UID, side.Name, v.(string))
+				}
+				objs = append(objs, SecureChangeModificationObject{XsiType: existing.XsiType, Name: existing.Name, UID: existing.UID})
+			}
+			if len(objs) > 0 {
+				modifications.RemoveObjects = ruleModificationObjects(side.Name, objs)
+			}
+		}
+
+		switch side.Name {
+		case "sources":
+			modification.SourceModifications = modifications
+		case "destinations":
+			modification.DestinationModifications = modifications
+		default:
+			modification.ServiceModifications = modifications
+		}
+	}
+	return &modification, nil
+}
+
+// ruleModificationObjects places objects in the network object or service list matching the rule side
+func ruleModificationObjects(side string, objs []SecureChangeModificationObject) *SecureChangeModificationObjects {
+	if side == "services" {
+		return &SecureChangeModificationObjects{Service: objs}
+	}
+	return &SecureChangeModificationObjects{NetworkObject: objs}
+}
+
+func resourceRuleModificationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	modification, err := ruleModification(d, m, false)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	subject := "Modify rule " + d.Get("rule_uid").(string) + " on " + d.Get("device").(string)
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_rule_modification", "create", "device", "rule_uid", "add_sources", "remove_sources", "add_destinations", "remove_destinations", "add_services", "remove_services")
+	ticketID, err := modifyRules(ctx, &client.SecureChange, workflow, subject, []SecureChangeRuleModification{*modification}, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("rule modification creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+	d.Set("ticket_id", ticketID)
+
+	return append(opts.Warnings.Diagnostics(), resourceRuleModificationRead(ctx, d, m)...)
+}
+
+func resourceRuleModificationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	deviceIDs, err := resolveDeviceIDs(&client.SecureTrack, []interface{}{d.Get("device").(string)})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	rule, err := secureTrack(&client.SecureTrack).GetDeviceRuleByUID(deviceIDs[0], d.Get("rule_uid").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// A rule that is gone cannot carry the modification any more
+	if rule == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("rule_number", rule.RuleNumber)
+	d.Set("sources", ruleObjectNames(rule.SrcNetwork))
+	d.Set("destinations", ruleObjectNames(rule.DstNetwork))
+	d.Set("services", ruleObjectNames(rule.DstService))
+
+	objects := map[string][]SecureTrackRuleObject{
+		"sources":      rule.SrcNetwork,
+		"destinations": rule.DstNetwork,
+		"services":     rule.DstService,
+	}
+	implemented := true
+	current := map[string][]interface{}{}
+	for _, side := range ruleModificationSides {
+		for _, v := range d.Get(side.Add).([]interface{}) {
+			if ruleHasObject(objects[side.Name], v.(string)) {
+				current[side.Add] = append(current[side.Add], v)
+			} else {
+				implemented = false
+			}
+		}
+		for _, v := range d.Get(side.Remove).([]interface{}) {
+			if !ruleHasObject(objects[side.Name], v.(string)) {
+				current[side.Remove] = append(current[side.Remove], v)
+			} else {
+				implemented = false
+			}
+		}
+	}
+
+	// Until the ticket is implemented the rule is expected to differ. Once it has been, the arguments are narrowed
+	// to what the rule still reflects so that a change made outside Terraform shows up as drift.
+	if d.Get("implemented").(bool) && !implemented {
+		for _, side := range ruleModificationSides {
+			d.Set(side.Add, current[side.Add])
+			d.Set(side.Remove, current[side.Remove])
+		}
+	} else {
+		d.Set("implemented", implemented)
+	}
+
+	return diags
+}
+
+func resourceRuleModificationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only the workflow, revert_on_destroy and the ticket settings can change in place, and they only affect later tickets
+	return resourceRuleModificationRead(ctx, d, m)
+}
+
+func resourceRuleModificationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	done, err := cancelPendingObjectTicket(&client.SecureChange, d, "modification of rule "+d.Get("rule_uid").(string), func() (bool, error) {
+		return d.Get("implemented").(bool), nil
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if done || !d.Get("revert_on_destroy").(bool) || !d.Get("implemented").(bool) {
+		d.SetId("")
+		return diags
+	}
+
+	modification, err := ruleModification(d, m, true)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	subject := "Revert modification of rule " + d.Get("rule_uid").(string) + " on " + d.Get("device").(string)
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_rule_modification", "delete", "device", "rule_uid", "add_sources", "remove_sources", "add_destinations", "remove_destinations", "add_services", "remove_services")
+	ticketID, err := modifyRules(ctx, &client.SecureChange, workflow, subject, []SecureChangeRuleModification{*modification}, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("rule modification deletion", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId("")
+
+	return append(diags, opts.Warnings.Diagnostics()...)
+}
@@ -0,0 +1,227 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceServiceGroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceGroupCreate,
+		ReadContext:   resourceServiceGroupRead,
+		UpdateContext: resourceServiceGroupUpdate,
+		DeleteContext: resourceServiceGroupDelete,
+		// Waiting for the ticket may take a human approval
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"members": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Set: schema.HashString,
+			},
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Service Group Change Template",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the ticket to be implemented, cancelling it if the apply is interrupted or times out.",
+			},
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"implemented": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// serviceGroupMembers marks every service in the set with the given member status
+func serviceGroupMembers(set *schema.Set, status string, members map[string]string) map[string]string {
+	for _, name := range set.List() {
+		members[name.(string)] = status
+	}
+	return members
+}
+
+func resourceServiceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+	name := d.Get("name").(string)
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	members := serviceGroupMembers(d.Get("members").(*schema.Set), "ADDED", map[string]string{})
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_group", "create", "name", "members", "devices")
+	ticketID, err := changeServiceGroup(ctx, &client.SecureChange, workflow, name, "CREATE", members, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service group creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceServiceGroupRead(ctx, d, m)...)
+}
+
+func resourceServiceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	name := d.Get("name").(string)
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found := 0
+	for _, mgmtID := range mgmtIDs {
+		group, err := getDeviceServiceByName(&client.SecureTrack, name, strconv.FormatInt(mgmtID, 10))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if group == nil {
+			continue
+		}
+		found++
+		// The first device holding the group is treated as authoritative for drift detection
+		if found == 1 {
+			members := make([]interface{}, 0, len(group.Member))
+			for _, member := range group.Member {
+				members = append(members, member.DisplayName)
+			}
+			d.Set("members", schema.NewSet(schema.HashString, members))
+		}
+	}
+
+	// Groups only appear in SecureTrack once their ticket is implemented, so a missing group is not removed from state
+	d.Set("implemented", found == len(mgmtIDs))
+
+	return diags
+}
+
+func resourceServiceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Ticket settings only apply to future tickets, so changing nothing else needs no ticket
+	if !d.HasChanges("members") {
+		return resourceServiceGroupRead(ctx, d, m)
+	}
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+	name := d.Get("name").(string)
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	old_members, new_members := d.GetChange("members")
+	members := map[string]string{}
+	serviceGroupMembers(old_members.(*schema.Set).Difference(new_members.(*schema.Set)), "DELETED", members)
+	serviceGroupMembers(new_members.(*schema.Set).Difference(old_members.(*schema.Set)), "ADDED", members)
+	if len(members) == 0 {
+		return resourceServiceGroupRead(ctx, d, m)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_group", "update", "name", "members", "devices")
+	ticketID, err := changeServiceGroup(ctx, &client.SecureChange, workflow, name, "UPDATE", members, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service group update", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceServiceGroupRead(ctx, d, m)...)
+}
+
+func resourceServiceGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	done, err := cancelPendingObjectTicket(&client.SecureChange, d, "service group "+d.Get("name").(string), func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if done {
+		d.SetId("")
+		return diags
+	}
+
+	name := d.Get("name").(string)
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_group", "delete", "name", "members", "devices")
+	ticketID, err := changeServiceGroup(ctx, &client.SecureChange, workflow, name, "DELETE", map[string]string{}, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service group deletion", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId("")
+
+	return append(diags, opts.Warnings.Diagnostics()...)
+}
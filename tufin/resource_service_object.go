@@ -0,0 +1,261 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceServiceObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceServiceObjectCreate,
+		ReadContext:   resourceServiceObjectRead,
+		UpdateContext: resourceServiceObjectUpdate,
+		DeleteContext: resourceServiceObjectDelete,
+		// Waiting for the ticket may take a human approval
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+		},
+		CustomizeDiff: resourceServiceObjectCustomizeDiff,
+		Schema: ticketOptionsSchema(map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Required:     true,
+				ValidateFunc: validateServiceProtocol,
+			},
+			"port": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"icmp_type"},
+				ValidateFunc:  validatePortRange,
+			},
+			"icmp_type": &schema.Schema{
+				Type:          schema.TypeInt,
+				Optional:      true,
+				ConflictsWith: []string{"port"},
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"devices": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Service Object Change Template",
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait for the ticket to be implemented, cancelling it if the apply is interrupted or times out.",
+			},
+			"ticket_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"implemented": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+		}, false),
+		SchemaVersion: 1,
+	}
+}
+
+// resourceServiceObjectCustomizeDiff rejects protocol, port and icmp_type combinations at plan time rather than at apply
+func resourceServiceObjectCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Values computed from other resources are only checked once they are known
+	if !d.NewValueKnown("protocol") || !d.NewValueKnown("port") || !d.NewValueKnown("icmp_type") {
+		return nil
+	}
+	_, hasPort := d.GetOk("port")
+	_, hasICMPType := d.GetOk("icmp_type")
+	return validateServiceArguments(d.Get("protocol").(string), hasPort, hasICMPType)
+}
+
+// serviceObjectChange builds the object change for a tufin_service_object from its configured arguments
+func serviceObjectChange(d *schema.ResourceData, action string) (SecureChangeServiceObjectChange, error) {
+	service, err := parseServiceDefinition(d.Get("protocol").(string), d.Get("port").(string), d.Get("icmp_type").(int))
+	if err != nil {
+		return SecureChangeServiceObjectChange{}, err
+	}
+	return SecureChangeServiceObjectChange{
+		ChangeAction:  action,
+		Name:          d.Get("name").(string),
+		ObjectType:    service.ObjectType(),
+		ObjectDetails: service.ObjectDetails(),
+		Comment:       d.Get("comment").(string),
+	}, nil
+}
+
+func resourceServiceObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	change, err := serviceObjectChange(d, "CREATE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_object", "create", "name", "protocol", "port", "icmp_type", "comment", "devices")
+	ticketID, err := changeServiceObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service object creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	newUuid, _ := uuid.GenerateUUID()
+	d.SetId(newUuid)
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceServiceObjectRead(ctx, d, m)...)
+}
+
+func resourceServiceObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	name := d.Get("name").(string)
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	found := 0
+	for _, mgmtID := range mgmtIDs {
+		service, err := getDeviceServiceByName(&client.SecureTrack, name, strconv.FormatInt(mgmtID, 10))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if service == nil {
+			continue
+		}
+		found++
+		// The first device holding the service is treated as authoritative for drift detection
+		if found == 1 {
+			definition := serviceDefinition{Protocol: serviceProtocolName(service.Protocol), Min: service.Min, Max: service.Max}
+			if definition.Protocol == "icmp" {
+				d.Set("icmp_type", definition.Min)
+			} else if definition.Protocol != "" {
+				d.Set("port", definition.Port())
+			}
+			d.Set("comment", service.Comment)
+		}
+	}
+
+	// Services only appear in SecureTrack once their ticket is implemented, so a missing service is not removed from state
+	d.Set("implemented", found == len(mgmtIDs))
+
+	return diags
+}
+
+func resourceServiceObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Ticket settings only apply to future tickets, so changing nothing else needs no ticket
+	if !d.HasChanges("port", "icmp_type", "comment") {
+		return resourceServiceObjectRead(ctx, d, m)
+	}
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	change, err := serviceObjectChange(d, "UPDATE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_object", "update", "name", "protocol", "port", "icmp_type", "comment", "devices")
+	ticketID, err := changeServiceObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service object update", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.Set("ticket_id", ticketID)
+
+	if err := waitForObjectTicket(ctx, &client.SecureChange, d, ticketID, func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	}); err != nil {
+		return append(opts.Warnings.Diagnostics(), diag.FromErr(err)...)
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceServiceObjectRead(ctx, d, m)...)
+}
+
+func resourceServiceObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	mgmtIDs, err := resolveDeviceIDs(&client.SecureTrack, d.Get("devices").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	done, err := cancelPendingObjectTicket(&client.SecureChange, d, "service object "+d.Get("name").(string), func() (bool, error) {
+		return serviceOnAnyDevice(&client.SecureTrack, d.Get("name").(string), mgmtIDs)
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if done {
+		d.SetId("")
+		return diags
+	}
+
+	change, err := serviceObjectChange(d, "DELETE")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	workflow := SecureChangeWorkflow{Name: d.Get("workflow").(string)}
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_service_object", "delete", "name", "protocol", "port", "icmp_type", "comment", "devices")
+	ticketID, err := changeServiceObject(ctx, &client.SecureChange, workflow, change, mgmtIDs, opts)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("service object deletion", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId("")
+
+	return append(diags, opts.Warnings.Diagnostics()...)
+}
@@ -0,0 +1,213 @@
+package tufin
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceTicket() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceTicketCreate,
+		ReadContext:   resourceTicketRead,
+		UpdateContext: resourceTicketUpdate,
+		DeleteContext: resourceTicketDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"workflow": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"step": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"priority": &schema.Schema{
+				Type:         schema.TypeString,
+				ForceNew:     true,
+				Optional:     true,
+				ValidateFunc: validateTicketPriority,
+				Description:  "Ticket priority, overriding the provider's ticket_priority.",
+			},
+			"requester": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Optional: true,
+			},
+			"justification": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Optional:    true,
+				Description: "Business justification made available to the provider's ticket templates.",
+			},
+			"auto_advance_steps": autoAdvanceStepsSchema(true),
+			"field": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							ForceNew: true,
+							Required: true,
+						},
+						"type": &schema.Schema{
+							Type:         schema.TypeString,
+							ForceNew:     true,
+							Required:     true,
+							ValidateFunc: validateTicketFieldType,
+						},
+						"value": &schema.Schema{
+							Type:     schema.TypeString,
+							ForceNew: true,
+							Optional: true,
+						},
+						"values": &schema.Schema{
+							Type:     schema.TypeList,
+							ForceNew: true,
+							Optional: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"wait_for_completion": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"current_step": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"steps": ticketStepsSchema(),
+		},
+		SchemaVersion: 1,
+	}
+}
+
+// expandTicket builds the ticket described by a tufin_ticket resource
+func expandTicket(d *schema.ResourceData) (*SecureChangeTicket, error) {
+	fields := []interface{}{}
+	for _, f := range d.Get("field").([]interface{}) {
+		field := f.(map[string]interface{})
+		values := []string{}
+		for _, v := range field["values"].([]interface{}) {
+			values = append(values, v.(string))
+		}
+		built, err := ticketField(field["type"].(string), field["name"].(string), field["value"].(string), values)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, built)
+	}
+
+	ticket := singleStepTicket(d.Get("subject").(string), SecureChangeWorkflow{Name: d.Get("workflow").(string)}, d.Get("step").(string), fields...)
+	ticket.Requester = d.Get("requester").(string)
+	return ticket, nil
+}
+
+func resourceTicketCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	meta := m.(*providerMeta)
+	client := meta.Client
+
+	ticket, err := expandTicket(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	opts := meta.ticketOptions(d).withChanges(d, "tufin_ticket", "create", "workflow", "step", "subject", "field")
+	ticketID, err := submitTicket(ctx, &client.SecureChange, ticket, opts, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("ticket creation", "opened ticket "+strconv.FormatInt(ticketID, 10))
+
+	d.SetId(strconv.FormatInt(ticketID, 10))
+
+	if d.Get("wait_for_completion").(bool) {
+		cancelled, err := waitForTicketOrCancel(ctx, &client.SecureChange, ticketID)
+		if cancelled {
+			d.SetId("")
+		}
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return append(opts.Warnings.Diagnostics(), resourceTicketRead(ctx, d, m)...)
+}
+
+func resourceTicketRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	ticketID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	ticket, err := secureChange(&client.SecureChange).GetTicket(ticketID)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if ticket == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("status", ticket.Status)
+	if ticket.CurrentStep != nil {
+		d.Set("current_step", ticket.CurrentStep.Name)
+	} else {
+		d.Set("current_step", "")
+	}
+	d.Set("steps", flattenTicketSteps(ticket))
+
+	return diags
+}
+
+func resourceTicketUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Only wait_for_completion can change in place, and it has no effect on the ticket itself
+	return resourceTicketRead(ctx, d, m)
+}
+
+func resourceTicketDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	ticketID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Tickets are an audit record in SecureChange, so destroying the resource only forgets a finished ticket,
+	// while one still open is cancelled so it cannot make the change later
+	if _, err := cancelOpenTicket(&client.SecureChange, ticketID, "Cancelled by Terraform: the ticket was destroyed before it was implemented."); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,209 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// uspExceptionDateLayout is the layout of USP exception expiration dates
+const uspExceptionDateLayout = "2006-01-02"
+
+func resourceUSPException() *schema.Resource {
+	addresses := func() *schema.Schema {
+		return &schema.Schema{
+			Type:     schema.TypeList,
+			ForceNew: true,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateFunc:     validateNetworkAddress,
+				DiffSuppressFunc: suppressEquivalentAddress,
+			},
+		}
+	}
+	return &schema.Resource{
+		CreateContext: resourceUSPExceptionCreate,
+		ReadContext:   resourceUSPExceptionRead,
+		DeleteContext: resourceUSPExceptionDelete,
+		CustomizeDiff: resourceUSPExceptionCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"sources":      addresses(),
+			"destinations": addresses(),
+			"services": &schema.Schema{
+				Type:     schema.TypeList,
+				ForceNew: true,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					DiffSuppressFunc: suppressEquivalentService,
+				},
+			},
+			"justification": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"requester": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"expiration_date": &schema.Schema{
+				Type:        schema.TypeString,
+				ForceNew:    true,
+				Required:    true,
+				Description: "Last day the exception applies, as YYYY-MM-DD.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := time.Parse(uspExceptionDateLayout, val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%q must be a date in YYYY-MM-DD format.", key))
+					}
+					return
+				},
+			},
+			"expired": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the exception's expiration date has passed. An expired exception stays in SecureTrack but exempts nothing until expiration_date is extended, which replaces it.",
+			},
+		},
+		SchemaVersion: 1,
+	}
+}
+
+// uspExceptionExpired reports whether an exception expiring on the given date no longer applies
+func uspExceptionExpired(date string) bool {
+	// SecureTrack may return the date with a time, but exceptions last until the end of their expiration date
+	if len(date) > len(uspExceptionDateLayout) {
+		date = date[:len(uspExceptionDateLayout)]
+	}
+	expires, err := time.Parse(uspExceptionDateLayout, date)
+	if err != nil {
+		return false
+	}
+	return !time.Now().UTC().Before(expires.AddDate(0, 0, 1))
+}
+
+// resourceUSPExceptionCustomizeDiff rejects an expiration date that has already passed when the exception is about to
+// be created. An exception that expired since then is left alone, so that it can still be planned and destroyed.
+func resourceUSPExceptionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && !d.HasChange("expiration_date") {
+		return nil
+	}
+	if expiration := d.Get("expiration_date").(string); uspExceptionExpired(expiration) {
+		return fmt.Errorf("expiration_date %s has passed. Remove the exception from the configuration or extend its expiration date.", expiration)
+	}
+	return nil
+}
+
+func resourceUSPExceptionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+
+	expiration := d.Get("expiration_date").(string)
+	if uspExceptionExpired(expiration) {
+		return diag.FromErr(fmt.Errorf("USP exception %s expired on %s. Remove it from the configuration or extend its expiration_date.", d.Get("name").(string), expiration))
+	}
+
+	sources, err := exemptedNetworks(d.Get("sources").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	destinations, err := exemptedNetworks(d.Get("destinations").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	services, err := exemptedServices(d.Get("services").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exception := SecureTrackUSPException{
+		Name:           d.Get("name").(string),
+		ExpirationDate: expiration,
+		RequestedBy:    d.Get("requester").(string),
+		Description:    d.Get("justification").(string),
+	}
+	exception.ExemptedTrafficList.ExemptedTraffic = []SecureTrackExemptedTraffic{{
+		Source:  sources,
+		Dest:    destinations,
+		Service: services,
+	}}
+
+	id, err := createUSPException(&client.SecureTrack, exception)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("usp exception creation", "created exception "+id)
+
+	d.SetId(id)
+
+	return resourceUSPExceptionRead(ctx, d, m)
+}
+
+func resourceUSPExceptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	exception, err := getUSPException(&client.SecureTrack, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if exception == nil {
+		d.SetId("")
+		return diags
+	}
+
+	// An expired exception no longer exempts anything but stays in SecureTrack, so it is kept in state with expired
+	// set, showing up as drift, until its expiration_date is extended or it is removed from the configuration
+	expired := uspExceptionExpired(exception.ExpirationDate)
+	d.Set("expired", expired)
+	if expired {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("USP exception %s has expired", exception.Name),
+			Detail:   fmt.Sprintf("The exception expired on %s. Remove it from the configuration or extend its expiration_date.", exception.ExpirationDate),
+		})
+	}
+
+	d.Set("name", exception.Name)
+	d.Set("justification", exception.Description)
+	d.Set("requester", exception.RequestedBy)
+	if len(exception.ExpirationDate) >= len(uspExceptionDateLayout) {
+		d.Set("expiration_date", exception.ExpirationDate[:len(uspExceptionDateLayout)])
+	}
+	sources, destinations, services := []interface{}{}, []interface{}{}, []interface{}{}
+	for _, traffic := range exception.ExemptedTrafficList.ExemptedTraffic {
+		sources = append(sources, flattenExemptedNetworks(traffic.Source)...)
+		destinations = append(destinations, flattenExemptedNetworks(traffic.Dest)...)
+		services = append(services, flattenExemptedServices(traffic.Service)...)
+	}
+	d.Set("sources", sources)
+	d.Set("destinations", destinations)
+	d.Set("services", services)
+
+	return diags
+}
+
+func resourceUSPExceptionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	if err := deleteUSPException(&client.SecureTrack, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
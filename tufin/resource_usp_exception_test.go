@@ -0,0 +1,29 @@
+package tufin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUSPExceptionExpired(t *testing.T) {
+	today := time.Now().UTC()
+	cases := []struct {
+		date    string
+		expired bool
+	}{
+		{date: today.AddDate(0, 0, -1).Format(uspExceptionDateLayout), expired: true},
+		{date: today.AddDate(-1, 0, 0).Format(uspExceptionDateLayout), expired: true},
+		{date: today.AddDate(0, 0, -1).Format(uspExceptionDateLayout) + "T12:00:00Z", expired: true},
+		{date: today.Format(uspExceptionDateLayout), expired: false},
+		{date: today.Format(uspExceptionDateLayout) + "T00:00:00Z", expired: false},
+		{date: today.AddDate(0, 0, 1).Format(uspExceptionDateLayout), expired: false},
+		{date: "not-a-date", expired: false},
+		{date: "", expired: false},
+	}
+
+	for _, c := range cases {
+		if actual := uspExceptionExpired(c.date); actual != c.expired {
+			t.Errorf("uspExceptionExpired(%q) = %t, expected %t", c.date, actual, c.expired)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceUSPMatrix() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUSPMatrixCreate,
+		ReadContext:   resourceUSPMatrixRead,
+		UpdateContext: resourceUSPMatrixUpdate,
+		DeleteContext: resourceUSPMatrixDelete,
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			// Exported matrices do not keep the order cells were imported in, so cells are a set
+			"cell": &schema.Schema{
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"from_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"to_zone": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"access": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+								if _, ok := uspAccessTypes[val.(string)]; !ok {
+									errs = append(errs, fmt.Errorf("%q must be one of allow_all, block_all, allow_only or block_only.", key))
+								}
+								return
+							},
+						},
+						"services": &schema.Schema{
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Services allowed by allow_only, or blocked by block_only, e.g. \"tcp 443\".",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"severity": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "high",
+							ValidateFunc: validateRiskSeverity,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		SchemaVersion: 1,
+	}
+}
+
+// expandUSPCells builds the matrix cells described by a tufin_usp_matrix resource
+func expandUSPCells(d *schema.ResourceData) []uspCell {
+	cells := []uspCell{}
+	for _, c := range d.Get("cell").(*schema.Set).List() {
+		cell := c.(map[string]interface{})
+		services := []string{}
+		for _, s := range cell["services"].([]interface{}) {
+			services = append(services, s.(string))
+		}
+		cells = append(cells, uspCell{
+			FromZone:    cell["from_zone"].(string),
+			ToZone:      cell["to_zone"].(string),
+			Access:      cell["access"].(string),
+			Services:    services,
+			Severity:    cell["severity"].(string),
+			Description: cell["description"].(string),
+		})
+	}
+	return cells
+}
+
+// flattenUSPCells converts matrix cells into the cell blocks of a tufin_usp_matrix resource
+func flattenUSPCells(cells []uspCell) []interface{} {
+	flattened := make([]interface{}, 0, len(cells))
+	for _, cell := range cells {
+		flattened = append(flattened, map[string]interface{}{
+			"from_zone":   cell.FromZone,
+			"to_zone":     cell.ToZone,
+			"access":      cell.Access,
+			"services":    cell.Services,
+			"severity":    cell.Severity,
+			"description": cell.Description,
+		})
+	}
+	return flattened
+}
+
+func resourceUSPMatrixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+
+	data, err := marshalUSP(expandUSPCells(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := importSecurityPolicy(&client.SecureTrack, d.Get("name").(string), data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("usp matrix creation", "imported security policy "+id)
+
+	d.SetId(id)
+
+	return resourceUSPMatrixRead(ctx, d, m)
+}
+
+func resourceUSPMatrixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	data, err := exportSecurityPolicy(&client.SecureTrack, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if data == nil {
+		d.SetId("")
+		return diags
+	}
+
+	cells, err := unmarshalUSP(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("cell", flattenUSPCells(cells))
+
+	return diags
+}
+
+func resourceUSPMatrixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+	name := d.Get("name").(string)
+
+	data, err := marshalUSP(expandUSPCells(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// SecureTrack only imports whole matrices and names must be unique, so the new cells are first imported under a
+	// temporary name. The old matrix is only deleted once they were accepted, and is never lost on a failed import.
+	tempName := name + " (terraform update)"
+	tempID, err := importSecurityPolicy(&client.SecureTrack, tempName, data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := deleteSecurityPolicy(&client.SecureTrack, d.Id()); err != nil {
+		deleteSecurityPolicy(&client.SecureTrack, tempID)
+		return diag.FromErr(err)
+	}
+	id, err := importSecurityPolicy(&client.SecureTrack, name, data)
+	if err != nil {
+		// The temporary matrix now holds the cells, so state follows it and the next plan replaces it under its name
+		d.SetId(tempID)
+		d.Set("name", tempName)
+		return diag.Errorf("Could not re-import security policy %s, its cells are kept in %s: %s", name, tempName, err)
+	}
+	debugLogOutput("usp matrix update", "re-imported security policy "+id)
+	d.SetId(id)
+
+	if err := deleteSecurityPolicy(&client.SecureTrack, tempID); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Could not delete temporary security policy " + tempName,
+			Detail:   err.Error(),
+		})
+	}
+
+	return append(diags, resourceUSPMatrixRead(ctx, d, m)...)
+}
+
+func resourceUSPMatrixDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	if err := deleteSecurityPolicy(&client.SecureTrack, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
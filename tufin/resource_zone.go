@@ -0,0 +1,92 @@
+package tufin
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceZone() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZoneCreate,
+		ReadContext:   resourceZoneRead,
+		UpdateContext: resourceZoneUpdate,
+		DeleteContext: resourceZoneDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		SchemaVersion: 1,
+	}
+}
+
+func resourceZoneCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+
+	id, err := createZone(&client.SecureTrack, SecureTrackZone{
+		Name:    d.Get("name").(string),
+		Comment: d.Get("comment").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("zone creation", "created zone "+id)
+
+	d.SetId(id)
+
+	return resourceZoneRead(ctx, d, m)
+}
+
+func resourceZoneRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	zone, err := getZone(&client.SecureTrack, d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if zone == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("name", zone.Name)
+	d.Set("comment", zone.Comment)
+
+	return diags
+}
+
+func resourceZoneUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+
+	err := updateZone(&client.SecureTrack, d.Id(), d.Get("name").(string), d.Get("comment").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceZoneRead(ctx, d, m)
+}
+
+func resourceZoneDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	if err := deleteZone(&client.SecureTrack, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
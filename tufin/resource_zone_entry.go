@@ -0,0 +1,159 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceZoneEntry() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceZoneEntryCreate,
+		ReadContext:   resourceZoneEntryRead,
+		UpdateContext: resourceZoneEntryUpdate,
+		DeleteContext: resourceZoneEntryDelete,
+		// Entries are imported by zone_id/entry_id, which Read splits
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"zone_id": &schema.Schema{
+				Type:     schema.TypeString,
+				ForceNew: true,
+				Required: true,
+			},
+			"subnet": &schema.Schema{
+				Type:             schema.TypeString,
+				ForceNew:         true,
+				Required:         true,
+				ValidateFunc:     validateZoneSubnet,
+				DiffSuppressFunc: suppressEquivalentAddress,
+			},
+			"comment": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		SchemaVersion: 1,
+	}
+}
+
+// zoneEntry builds the zone entry for a host or subnet, using a netmask for IPv4 and a prefix length for IPv6
+func zoneEntry(subnet string, comment string) (SecureTrackZoneEntry, error) {
+	addr, err := parseNetworkAddress(subnet)
+	if err != nil {
+		return SecureTrackZoneEntry{}, err
+	}
+	entry := SecureTrackZoneEntry{IP: addr.IP, Comment: comment}
+	if strings.Contains(addr.Mask, ".") {
+		entry.Netmask = addr.Mask
+	} else {
+		entry.Prefix, _ = strconv.Atoi(addr.Mask)
+	}
+	return entry, nil
+}
+
+// validateZoneSubnet is a schema ValidateFunc for zone entry subnets, which may be hosts or subnets but not ranges
+func validateZoneSubnet(val interface{}, key string) (warns []string, errs []error) {
+	addr, err := parseNetworkAddress(val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%q is invalid: %s. May be an IPv4/IPv6 host or CIDR subnet.", key, err))
+	} else if addr.LastIP != "" {
+		errs = append(errs, fmt.Errorf("%q cannot be an address range.", key))
+	}
+	return
+}
+
+// zoneEntryID splits a tufin_zone_entry ID into its zone and entry IDs
+func zoneEntryID(id string) (string, string, error) {
+	parts := strings.SplitN(id, "/", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("Zone entry ID %q is not in zone_id/entry_id form", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+func resourceZoneEntryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+	zoneID := d.Get("zone_id").(string)
+
+	entry, err := zoneEntry(d.Get("subnet").(string), d.Get("comment").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := createZoneEntry(&client.SecureTrack, zoneID, entry)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	debugLogOutput("zone entry creation", "created entry "+id+" in zone "+zoneID)
+
+	d.SetId(zoneID + "/" + id)
+
+	return resourceZoneEntryRead(ctx, d, m)
+}
+
+func resourceZoneEntryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	zoneID, id, err := zoneEntryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	entry, err := getZoneEntry(&client.SecureTrack, zoneID, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if entry == nil {
+		d.SetId("")
+		return diags
+	}
+
+	d.Set("zone_id", zoneID)
+	if subnet := zoneEntryAddress(entry); subnet != "" {
+		d.Set("subnet", subnet)
+	}
+	d.Set("comment", entry.Comment)
+
+	return diags
+}
+
+func resourceZoneEntryUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*providerMeta).Client
+
+	zoneID, id, err := zoneEntryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	entry, err := zoneEntry(d.Get("subnet").(string), d.Get("comment").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := updateZoneEntry(&client.SecureTrack, zoneID, id, entry); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceZoneEntryRead(ctx, d, m)
+}
+
+func resourceZoneEntryDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client := m.(*providerMeta).Client
+
+	zoneID, id, err := zoneEntryID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := deleteZoneEntry(&client.SecureTrack, zoneID, id); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,227 @@
+package tufin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// rulesSchema is the computed schema for a list of security rules, shared by the rule data sources
+func rulesSchema() *schema.Schema {
+	computedString := &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	computedInt := &schema.Schema{
+		Type:     schema.TypeInt,
+		Computed: true,
+	}
+	computedBool := &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+	computedStrings := &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id":           computedString,
+				"uid":          computedString,
+				"number":       computedInt,
+				"name":         computedString,
+				"action":       computedString,
+				"sources":      computedStrings,
+				"destinations": computedStrings,
+				"services":     computedStrings,
+				"comment":      computedString,
+				"disabled":     computedBool,
+				"last_hit":     computedString,
+			},
+		},
+	}
+}
+
+// flattenRules converts security rules into the rulesSchema form, adding each rule's last hit from hits when known
+func flattenRules(rules []SecureTrackRule, hits map[string]string) []interface{} {
+	flattened := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		flattened = append(flattened, map[string]interface{}{
+			"id":           strconv.FormatInt(rule.ID, 10),
+			"uid":          rule.UID,
+			"number":       rule.RuleNumber,
+			"name":         rule.Name,
+			"action":       rule.Action,
+			"sources":      ruleObjectNames(rule.SrcNetwork),
+			"destinations": ruleObjectNames(rule.DstNetwork),
+			"services":     ruleObjectNames(rule.DstService),
+			"comment":      rule.Comment,
+			"disabled":     rule.Disabled,
+			"last_hit":     hits[rule.UID],
+		})
+	}
+	return flattened
+}
+
+// ruleObjectNames lists the names of the objects a rule references, preferring their display names
+func ruleObjectNames(objs []SecureTrackRuleObject) []string {
+	names := make([]string, 0, len(objs))
+	for _, obj := range objs {
+		if obj.DisplayName != "" {
+			names = append(names, obj.DisplayName)
+		} else {
+			names = append(names, obj.Name)
+		}
+	}
+	return names
+}
+
+// ruleDeviceID returns the SecureTrack device ID configured by a data source's device or device_id argument
+func ruleDeviceID(c *tufinclient.SecureTrackClient, d *schema.ResourceData) (int64, error) {
+	if id, ok := d.GetOk("device_id"); ok {
+		return int64(id.(int)), nil
+	}
+	ids, err := resolveDeviceIDs(c, []interface{}{d.Get("device").(string)})
+	if err != nil {
+		return 0, err
+	}
+	if len(ids) != 1 {
+		return 0, fmt.Errorf("Could not resolve device %s", d.Get("device").(string))
+	}
+	return ids[0], nil
+}
+
+// ruleDeviceSchema adds the device and device_id arguments identifying the device a rule data source reads
+func ruleDeviceSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["device"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ExactlyOneOf: []string{"device", "device_id"},
+		Description:  "Name or IP of the SecureTrack device.",
+	}
+	s["device_id"] = &schema.Schema{
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"device", "device_id"},
+		Description:  "SecureTrack ID of the device.",
+	}
+	return s
+}
+
+// ruleNetworkObjects converts source or destination arguments into rule modification objects. Addresses become new
+// host, subnet or range objects, while anything else must name an existing object on the device.
+func ruleNetworkObjects(c *tufinclient.SecureTrackClient, deviceID int64, values []interface{}) ([]SecureChangeModificationObject, error) {
+	objs := make([]SecureChangeModificationObject, 0, len(values))
+	for _, v := range values {
+		value := v.(string)
+		if addr, err := parseNetworkAddress(value); err == nil {
+			obj := SecureChangeModificationObject{Name: addr.Value}
+			switch addr.Type {
+			case "Range":
+				obj.XsiType, obj.FirstIP, obj.LastIP = "rangeNetworkObjectDTO", addr.IP, addr.LastIP
+			case "Network":
+				obj.XsiType, obj.IP, obj.Netmask = "subnetNetworkObjectDTO", addr.IP, addr.Mask
+			default:
+				obj.XsiType, obj.IP, obj.Netmask = "hostNetworkObjectDTO", addr.IP, addr.Mask
+			}
+			objs = append(objs, obj)
+			continue
+		}
+		existing, err := c.GetDeviceNetworkObjectByName(value, strconv.FormatInt(deviceID, 10), true)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("Network object %s does not exist on device %d", value, deviceID)
+		}
+		objs = append(objs, SecureChangeModificationObject{XsiType: existing.XsiType, Name: existing.Name, UID: existing.UID})
+	}
+	return objs, nil
+}
+
+// ruleServiceObjects converts service arguments such as "tcp 443" or "icmp 8" into rule modification objects,
+// treating anything else as the name of an existing service on the device
+func ruleServiceObjects(c *tufinclient.SecureTrackClient, deviceID int64, values []interface{}) ([]SecureChangeModificationObject, error) {
+	objs := make([]SecureChangeModificationObject, 0, len(values))
+	for _, v := range values {
+		value := v.(string)
+		if service, err := topologyServiceDefinition(value); err == nil {
+			xsiType := "transportServiceDTO"
+			if service.Protocol == "icmp" {
+				xsiType = "icmpServiceDTO"
+			}
+			objs = append(objs, SecureChangeModificationObject{
+				XsiType:  xsiType,
+				Name:     service.Protocol + "_" + service.Port(),
+				Protocol: serviceProtocols[service.Protocol],
+				Min:      service.Min,
+				Max:      service.Max,
+			})
+			continue
+		}
+		existing, err := getDeviceServiceByName(c, value, strconv.FormatInt(deviceID, 10))
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, fmt.Errorf("Service %s does not exist on device %d", value, deviceID)
+		}
+		objs = append(objs, SecureChangeModificationObject{XsiType: existing.XsiType, Name: existing.Name, UID: existing.UID})
+	}
+	return objs, nil
+}
+
+// ruleHasObject reports whether a rule references the object given by a source, destination or service argument
+func ruleHasObject(objs []SecureTrackRuleObject, value string) bool {
+	return ruleObject(objs, value) != nil
+}
+
+// ruleObject finds the object a rule references for a source, destination or service argument, matching names as
+// well as the address or port the argument describes. It returns nil when the rule has no such object.
+func ruleObject(objs []SecureTrackRuleObject, value string) *SecureTrackRuleObject {
+	addr, addrErr := parseNetworkAddress(value)
+	service, serviceErr := topologyServiceDefinition(value)
+	for i, obj := range objs {
+		if obj.Name == value || obj.DisplayName == value {
+			return &objs[i]
+		}
+		if addrErr == nil && obj.IP != "" {
+			objAddr := objectAddress(&tufinclient.SecureTrackNetworkObject{IP: obj.IP, Netmask: obj.Netmask})
+			if parsed, err := parseNetworkAddress(objAddr); err == nil && parsed.Value == addr.Value {
+				return &objs[i]
+			}
+		}
+		if serviceErr == nil && (obj.Name == service.Protocol+"_"+service.Port() || strings.EqualFold(obj.Name, service.ObjectDetails())) {
+			return &objs[i]
+		}
+	}
+	return nil
+}
+
+// bindingsAllow reports whether a device lets a flow through the policy bindings it crosses, given the action of the
+// first rule matching the flow in each binding, or an empty action when none matches. A device decides by its first
+// matching rule, so the flow is allowed only when there is a binding and every binding's first rule accepts, allows
+// or permits it. A binding with no matching rule drops the flow through its implicit deny.
+func bindingsAllow(actions []string) bool {
+	if len(actions) == 0 {
+		return false
+	}
+	for _, action := range actions {
+		switch strings.ToLower(action) {
+		case "accept", "allow", "permit":
+		default:
+			return false
+		}
+	}
+	return true
+}
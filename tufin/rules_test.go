@@ -0,0 +1,30 @@
+package tufin
+
+import (
+	"testing"
+)
+
+func TestBindingsAllow(t *testing.T) {
+	cases := []struct {
+		name    string
+		actions []string
+		allowed bool
+	}{
+		{name: "accept", actions: []string{"accept"}, allowed: true},
+		{name: "allow", actions: []string{"Allow"}, allowed: true},
+		{name: "permit", actions: []string{"PERMIT"}, allowed: true},
+		{name: "drop", actions: []string{"drop"}, allowed: false},
+		{name: "deny", actions: []string{"deny"}, allowed: false},
+		{name: "no matching rule", actions: []string{""}, allowed: false},
+		{name: "every binding allows", actions: []string{"accept", "permit"}, allowed: true},
+		{name: "one binding drops", actions: []string{"accept", "drop"}, allowed: false},
+		{name: "one binding has no matching rule", actions: []string{"accept", ""}, allowed: false},
+		{name: "no bindings", actions: []string{}, allowed: false},
+	}
+
+	for _, c := range cases {
+		if actual := bindingsAllow(c.actions); actual != c.allowed {
+			t.Errorf("%s: bindingsAllow(%q) = %t, expected %t", c.name, c.actions, actual, c.allowed)
+		}
+	}
+}
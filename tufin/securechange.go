@@ -0,0 +1,631 @@
+package tufin
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// groupChangeWorkflow is the SecureChange workflow used for network object group changes
+var groupChangeWorkflow = SecureChangeWorkflow{
+	ID:   34,
+	Name: "Group Change Template",
+}
+
+// submitTicket applies the ticket options and opens a SecureChange ticket, returning its ID. A 400 response
+// matching ignore is treated as a no-op, in which case the returned ID is 0.
+func submitTicket(ctx context.Context, c *tufinclient.SecureChangeClient, ticket *SecureChangeTicket, opts ticketOptions, ignore string) (int64, error) {
+	if err := opts.apply(ticket); err != nil {
+		return 0, err
+	}
+
+	response, err := c.R().
+		SetBody(SecureChangeTicketRequest{Ticket: *ticket}).
+		Post("/securechange/tickets.json")
+	if err != nil {
+		return 0, err
+	}
+
+	switch response.StatusCode() {
+	case 201:
+		// SecureChange returns the new ticket's URL in the Location header
+		id, err := strconv.ParseInt(path.Base(response.Header().Get("Location")), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Could not read ticket ID from SecureChange response location %q", response.Header().Get("Location"))
+		}
+		// The ticket is already open, so failing to annotate it must not fail the resource
+		if err := annotateTicket(c, id, opts); err != nil {
+			opts.Warnings.add(fmt.Sprintf("Could not add planned changes to ticket %d", id), err.Error())
+		}
+		// Steps left unfinished simply wait for a human, so a failed advance is not fatal either
+		if err := advanceTicket(ctx, c, id, opts.AutoAdvanceSteps, opts.ApprovalReason); err != nil {
+			opts.Warnings.add(fmt.Sprintf("Could not auto-advance ticket %d", id), err.Error())
+		}
+		return id, nil
+	case 400:
+		if ignore != "" {
+			matched, _ := regexp.MatchString(ignore, response.String())
+			if matched {
+				return 0, nil
+			}
+		}
+		return 0, fmt.Errorf("%s", response.String())
+	case 401:
+		return 0, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return 0, fmt.Errorf("%s", response.String())
+	}
+}
+
+// singleStepTicket builds a ticket whose only step submits the given fields
+func singleStepTicket(subject string, workflow SecureChangeWorkflow, step string, fields ...interface{}) *SecureChangeTicket {
+	return &SecureChangeTicket{
+		Priority: "Normal",
+		Subject:  subject,
+		Workflow: workflow,
+		Steps: SecureChangeSteps{
+			Step: []SecureChangeStep{
+				{
+					Name: step,
+					Tasks: SecureChangeTasks{
+						Task: []SecureChangeTask{
+							{
+								Fields: SecureChangeFields{
+									Field: fields,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// groupChangeTicket builds a single-step group change ticket updating one group on one device
+func groupChangeTicket(subject string, group string, mgmtID int64, members []SecureChangeGroupMember) *SecureChangeTicket {
+	return singleStepTicket(subject, groupChangeWorkflow, "Submit network object group request",
+		SecureChangeGroupChangeField{
+			XsiType: "multi_group_change",
+			Name:    "Modify network object group",
+			GroupChange: []SecureChangeGroupChange{
+				{
+					XsiType:      "group_change",
+					Name:         group,
+					ChangeAction: "UPDATE",
+					ManagementID: mgmtID,
+					Members: SecureChangeGroupMembers{
+						Member: members,
+					},
+				},
+			},
+		},
+	)
+}
+
+// addMemberToDeviceGroup adds a SecureChangeGroupMember to an existing group on a device
+func addMemberToDeviceGroup(ctx context.Context, c *tufinclient.SecureChangeClient, member *SecureChangeGroupMember, group string, managementID string, opts ticketOptions) (int64, error) {
+	mgmtID, err := strconv.ParseInt(managementID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not convert management_id %s to integer", managementID)
+	}
+	ticket := groupChangeTicket("Add member "+member.Name+" to Group "+group, group, mgmtID, []SecureChangeGroupMember{*member})
+	return submitTicket(ctx, c, ticket, opts, memberIgnorePattern(member, group))
+}
+
+// removeMemberFromDeviceGroup removes a SecureChangeGroupMember from an existing group on a device
+func removeMemberFromDeviceGroup(ctx context.Context, c *tufinclient.SecureChangeClient, member *SecureChangeGroupMember, group string, managementID string, opts ticketOptions) (int64, error) {
+	mgmtID, err := strconv.ParseInt(managementID, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Could not convert management_id %s to integer", managementID)
+	}
+	ticket := groupChangeTicket("Remove Member "+member.Name+" from Group "+group, group, mgmtID, []SecureChangeGroupMember{*member})
+	return submitTicket(ctx, c, ticket, opts, memberIgnorePattern(member, group))
+}
+
+// memberIgnorePattern matches the error SecureChange returns when a member change is already in effect, that is
+// when an added member is already in the group or a removed one is not
+func memberIgnorePattern(member *SecureChangeGroupMember, group string) string {
+	if member.Status == "DELETED" {
+		return fmt.Sprintf(`object with name %s in group %s does not exist`, regexp.QuoteMeta(member.Name), regexp.QuoteMeta(group))
+	}
+	return fmt.Sprintf(`object with name %s in group %s already exists`, regexp.QuoteMeta(member.Name), regexp.QuoteMeta(group))
+}
+
+// networkObjectChangeTicket builds a single-step ticket applying the same object change on each device
+func networkObjectChangeTicket(subject string, workflow SecureChangeWorkflow, change SecureChangeNetworkObjectChange, mgmtIDs []int64) *SecureChangeTicket {
+	changes := make([]SecureChangeNetworkObjectChange, 0, len(mgmtIDs))
+	for _, mgmtID := range mgmtIDs {
+		c := change
+		c.XsiType = "network_object_change"
+		c.ManagementID = mgmtID
+		changes = append(changes, c)
+	}
+	return singleStepTicket(subject, workflow, "Submit network object request",
+		SecureChangeNetworkObjectChangeField{
+			XsiType:             "multi_network_object_change",
+			Name:                "Modify network object",
+			NetworkObjectChange: changes,
+		},
+	)
+}
+
+// changeNetworkObject opens a ticket to CREATE, UPDATE or DELETE a standalone network object on the given devices
+func changeNetworkObject(ctx context.Context, c *tufinclient.SecureChangeClient, workflow SecureChangeWorkflow, change SecureChangeNetworkObjectChange, mgmtIDs []int64, opts ticketOptions) (int64, error) {
+	var subject string
+	switch change.ChangeAction {
+	case "CREATE":
+		subject = "Create network object " + change.Name
+	case "DELETE":
+		subject = "Delete network object " + change.Name
+	default:
+		subject = "Modify network object " + change.Name
+	}
+	ticket := networkObjectChangeTicket(subject, workflow, change, mgmtIDs)
+	return submitTicket(ctx, c, ticket, opts, "")
+}
+
+// changeServiceObject opens a ticket to CREATE, UPDATE or DELETE a service object on the given devices
+func changeServiceObject(ctx context.Context, c *tufinclient.SecureChangeClient, workflow SecureChangeWorkflow, change SecureChangeServiceObjectChange, mgmtIDs []int64, opts ticketOptions) (int64, error) {
+	var subject string
+	switch change.ChangeAction {
+	case "CREATE":
+		subject = "Create service " + change.Name
+	case "DELETE":
+		subject = "Delete service " + change.Name
+	default:
+		subject = "Modify service " + change.Name
+	}
+	changes := make([]SecureChangeServiceObjectChange, 0, len(mgmtIDs))
+	for _, mgmtID := range mgmtIDs {
+		c := change
+		c.XsiType = "service_object_change"
+		c.ManagementID = mgmtID
+		changes = append(changes, c)
+	}
+	ticket := singleStepTicket(subject, workflow, "Submit service object request",
+		SecureChangeServiceObjectChangeField{
+			XsiType:             "multi_service_object_change",
+			Name:                "Modify service object",
+			ServiceObjectChange: changes,
+		},
+	)
+	return submitTicket(ctx, c, ticket, opts, "")
+}
+
+// changeServiceGroup opens a ticket to CREATE, UPDATE or DELETE a service group on the given devices. Members
+// maps each service name to ADDED or DELETED.
+func changeServiceGroup(ctx context.Context, c *tufinclient.SecureChangeClient, workflow SecureChangeWorkflow, group string, action string, members map[string]string, mgmtIDs []int64, opts ticketOptions) (int64, error) {
+	var subject string
+	switch action {
+	case "CREATE":
+		subject = "Create service group " + group
+	case "DELETE":
+		subject = "Delete service group " + group
+	default:
+		subject = "Modify service group " + group
+	}
+	groupMembers := make([]SecureChangeServiceGroupMember, 0, len(members))
+	for name, status := range members {
+		groupMembers = append(groupMembers, SecureChangeServiceGroupMember{
+			Type:                "Object",
+			XsiType:             "groupMemberServiceObjectDTO",
+			Name:                name,
+			ObjectUpdatedStatus: "EXISTING_NOT_EDITED",
+			Status:              status,
+		})
+	}
+	changes := make([]SecureChangeServiceGroupChange, 0, len(mgmtIDs))
+	for _, mgmtID := range mgmtIDs {
+		changes = append(changes, SecureChangeServiceGroupChange{
+			XsiType:      "service_group_change",
+			ChangeAction: action,
+			ManagementID: mgmtID,
+			Members: SecureChangeServiceGroupMembers{
+				Member: groupMembers,
+			},
+			Name: group,
+		})
+	}
+	ticket := singleStepTicket(subject, workflow, "Submit service group request",
+		SecureChangeServiceGroupChangeField{
+			XsiType:     "multi_service_group_change",
+			Name:        "Modify service group",
+			GroupChange: changes,
+		},
+	)
+	return submitTicket(ctx, c, ticket, opts, "")
+}
+
+// ticketPollInterval is how often an open ticket is re-read while waiting for it to finish
+const ticketPollInterval = 30 * time.Second
+
+// SecureChangeClient adds the ticket read and search calls the provider needs to the tufinclient SecureChange client
+type SecureChangeClient struct {
+	*tufinclient.SecureChangeClient
+}
+
+// secureChange wraps a tufinclient SecureChange client with the provider's ticket calls
+func secureChange(c *tufinclient.SecureChangeClient) SecureChangeClient {
+	return SecureChangeClient{SecureChangeClient: c}
+}
+
+// GetTicket retrieves a SecureChange ticket by ID, returning nil when it does not exist
+func (c SecureChangeClient) GetTicket(id int64) (*SecureChangeTicket, error) {
+	response, err := c.R().
+		SetResult(&SecureChangeTicketRequest{}).
+		SetHeader("Accept", "application/json").
+		Get("/securechange/tickets/" + strconv.FormatInt(id, 10) + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return &response.Result().(*SecureChangeTicketRequest).Ticket, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// ticketFinished reports whether a ticket status is final, and whether the change it requested was carried out
+func ticketFinished(status string) (finished bool, implemented bool) {
+	switch status {
+	case "Resolved", "Ticket Closed":
+		return true, true
+	case "Ticket Rejected", "Ticket Cancelled":
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// waitForTicket polls a ticket until it resolves, failing if it is rejected or cancelled or the context ends first
+func waitForTicket(ctx context.Context, c *tufinclient.SecureChangeClient, id int64) (*SecureChangeTicket, error) {
+	for {
+		ticket, err := secureChange(c).GetTicket(id)
+		if err != nil {
+			return nil, err
+		}
+		if ticket == nil {
+			return nil, fmt.Errorf("Ticket %d no longer exists in SecureChange", id)
+		}
+		finished, implemented := ticketFinished(ticket.Status)
+		if finished && implemented {
+			return ticket, nil
+		}
+		if finished {
+			return ticket, fmt.Errorf("Ticket %d finished without being implemented: %s", id, ticket.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ticket, fmt.Errorf("Timed out waiting for ticket %d, last status %s: %s", id, ticket.Status, ctx.Err())
+		case <-time.After(ticketPollInterval):
+		}
+	}
+}
+
+// waitForTicketOrCancel waits for a ticket like waitForTicket, but cancels the ticket when the wait times out or is
+// interrupted so that it cannot implement the change later. cancelled reports whether the ticket was cancelled.
+func waitForTicketOrCancel(ctx context.Context, c *tufinclient.SecureChangeClient, id int64) (cancelled bool, err error) {
+	_, err = waitForTicket(ctx, c, id)
+	if err == nil || ctx.Err() == nil {
+		return false, err
+	}
+	reason := fmt.Sprintf("Cancelled by Terraform: the apply was interrupted or timed out (%s) before the ticket was implemented.", ctx.Err())
+	if _, cancelErr := cancelOpenTicket(c, id, reason); cancelErr != nil {
+		return false, fmt.Errorf("%s, and cancelling the ticket also failed: %s", err, cancelErr)
+	}
+	return true, err
+}
+
+// cancelOpenTicket cancels a ticket that has not finished yet, commenting the reason first. Tickets the API user is
+// not allowed to cancel are rejected instead. Finished and missing tickets are left alone, and cancelled reports
+// whether the ticket was still open.
+func cancelOpenTicket(c *tufinclient.SecureChangeClient, id int64, reason string) (cancelled bool, err error) {
+	ticket, err := secureChange(c).GetTicket(id)
+	if err != nil {
+		return false, err
+	}
+	if ticket == nil {
+		return false, nil
+	}
+	if finished, _ := ticketFinished(ticket.Status); finished {
+		return false, nil
+	}
+
+	// The reason also goes on the reject request, so a failed comment only loses it from the task history
+	if err := addTicketComment(c, id, reason, nil); err != nil {
+		debugLogOutput("ticket cancellation", fmt.Sprintf("could not comment on ticket %d: %s", id, err))
+	}
+
+	response, err := c.R().
+		Put(fmt.Sprintf("/securechange/tickets/%d/cancel", id))
+	if err != nil {
+		return false, err
+	}
+
+	switch response.StatusCode() {
+	case 200, 204:
+		debugLogOutput("ticket cancellation", fmt.Sprintf("cancelled ticket %d", id))
+		return true, nil
+	case 401:
+		return false, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 400, 403:
+		return true, rejectTicket(c, id, reason)
+	default:
+		return false, fmt.Errorf("%s", response.String())
+	}
+}
+
+// rejectTicket rejects an open ticket with the given reason
+func rejectTicket(c *tufinclient.SecureChangeClient, id int64, reason string) error {
+	response, err := c.R().
+		SetBody(SecureChangeRejectRequest{RejectComment: SecureChangeRejectComment{Comment: reason}}).
+		Put(fmt.Sprintf("/securechange/tickets/%d/reject", id))
+	if err != nil {
+		return err
+	}
+
+	switch response.StatusCode() {
+	case 200, 204:
+		debugLogOutput("ticket cancellation", fmt.Sprintf("rejected ticket %d", id))
+		return nil
+	case 401:
+		return fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return fmt.Errorf("%s", response.String())
+	}
+}
+
+// ticketSearchPageSize is how many tickets are requested per page of search results
+const ticketSearchPageSize = 100
+
+// SearchTickets searches SecureChange tickets, skipping any filter left empty and reading every page of results
+func (c SecureChangeClient) SearchTickets(subject string, status string, requester string, workflow string) ([]SecureChangeTicketSummary, error) {
+	params := map[string]string{
+		"count": strconv.Itoa(ticketSearchPageSize),
+	}
+	for key, value := range map[string]string{
+		"subject":       subject,
+		"status":        status,
+		"requester":     requester,
+		"workflow_name": workflow,
+	} {
+		if value != "" {
+			params[key] = value
+		}
+	}
+
+	tickets := []SecureChangeTicketSummary{}
+	for {
+		params["start"] = strconv.Itoa(len(tickets))
+		response, err := c.R().
+			SetResult(&SecureChangeTicketSearchResult{}).
+			SetQueryParams(params).
+			SetHeader("Accept", "application/json").
+			Get("/securechange/tickets/search.json")
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.StatusCode() {
+		case 401:
+			return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+		case 200:
+		default:
+			return nil, fmt.Errorf("%s", response.String())
+		}
+
+		page := response.Result().(*SecureChangeTicketSearchResult).TicketsSearchResults.TicketResult
+		tickets = append(tickets, page...)
+		if len(page) < ticketSearchPageSize {
+			return tickets, nil
+		}
+	}
+}
+
+// uploadAttachment uploads a file to SecureChange and returns the UID used to attach it to a ticket
+func uploadAttachment(c *tufinclient.SecureChangeClient, fileName string, data []byte) (string, error) {
+	response, err := c.R().
+		SetFileReader("attachment", fileName, bytes.NewReader(data)).
+		Post("/securechange/attachments")
+	if err != nil {
+		return "", err
+	}
+
+	switch response.StatusCode() {
+	case 200, 201:
+		return strings.TrimSpace(response.String()), nil
+	case 401:
+		return "", fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return "", fmt.Errorf("%s", response.String())
+	}
+}
+
+// addTicketComment adds a comment, with any previously uploaded attachments, to the task of a ticket's current step
+func addTicketComment(c *tufinclient.SecureChangeClient, id int64, content string, attachmentUIDs []string) error {
+	ticket, err := secureChange(c).GetTicket(id)
+	if err != nil {
+		return err
+	}
+	if ticket == nil || len(ticket.Steps.Step) == 0 {
+		return fmt.Errorf("Ticket %d has no steps to comment on", id)
+	}
+
+	// Closed tickets have no current step, in which case the comment goes on the final step
+	step := ticket.Steps.Step[len(ticket.Steps.Step)-1]
+	if ticket.CurrentStep != nil {
+		for _, s := range ticket.Steps.Step {
+			if s.ID == ticket.CurrentStep.ID {
+				step = s
+			}
+		}
+	}
+	if len(step.Tasks.Task) == 0 {
+		return fmt.Errorf("Step %s of ticket %d has no tasks to comment on", step.Name, id)
+	}
+
+	comment := SecureChangeTaskComment{Content: content}
+	if len(attachmentUIDs) > 0 {
+		comment.Attachments = &SecureChangeAttachmentRefs{}
+		for _, uid := range attachmentUIDs {
+			comment.Attachments.Attachment = append(comment.Attachments.Attachment, SecureChangeAttachmentRef{UID: uid})
+		}
+	}
+
+	response, err := c.R().
+		SetBody(SecureChangeTaskCommentRequest{Comment: comment}).
+		Post(fmt.Sprintf("/securechange/tickets/%d/steps/%d/tasks/%d/comments", id, step.ID, step.Tasks.Task[0].ID))
+	if err != nil {
+		return err
+	}
+
+	switch response.StatusCode() {
+	case 200, 201:
+		return nil
+	case 401:
+		return fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return fmt.Errorf("%s", response.String())
+	}
+}
+
+// ticketAdvanceInterval is how often a ticket is re-read while waiting for SecureChange to move it to its next step
+const ticketAdvanceInterval = 5 * time.Second
+
+// ticketAdvanceAttempts bounds how many times a ticket is re-read while auto-advancing it
+const ticketAdvanceAttempts = 24
+
+// advanceTicket completes the tasks of the ticket's current step for as long as that step is one of the named
+// steps, approving any approval field on the way. It stops at the first step that needs a human.
+func advanceTicket(ctx context.Context, c *tufinclient.SecureChangeClient, id int64, steps []string, reason string) error {
+	if len(steps) == 0 {
+		return nil
+	}
+
+	completed := map[int64]bool{}
+	for attempt := 0; attempt < ticketAdvanceAttempts; attempt++ {
+		ticket, err := secureChange(c).GetTicket(id)
+		if err != nil {
+			return err
+		}
+		if ticket == nil {
+			return fmt.Errorf("Ticket %d no longer exists in SecureChange", id)
+		}
+		if finished, _ := ticketFinished(ticket.Status); finished || ticket.CurrentStep == nil {
+			return nil
+		}
+
+		var step *SecureChangeStep
+		for i, s := range ticket.Steps.Step {
+			if s.ID == ticket.CurrentStep.ID {
+				step = &ticket.Steps.Step[i]
+			}
+		}
+		if step == nil || !containsStep(steps, step.Name) {
+			return nil
+		}
+
+		// SecureChange moves to the next step asynchronously, so a step already completed is simply waited on
+		if !completed[step.ID] {
+			for _, task := range step.Tasks.Task {
+				if task.Status == "DONE" {
+					continue
+				}
+				if err := completeTask(c, id, step.ID, task, reason); err != nil {
+					return fmt.Errorf("Could not complete step %s: %s", step.Name, err)
+				}
+			}
+			completed[step.ID] = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Stopped auto-advancing ticket %d at step %s: %s", id, step.Name, ctx.Err())
+		case <-time.After(ticketAdvanceInterval):
+		}
+	}
+	return fmt.Errorf("Ticket %d did not leave its auto-advanced steps in time", id)
+}
+
+// completeTask approves any approval field of a task with the given reason and marks it done
+func completeTask(c *tufinclient.SecureChangeClient, id int64, stepID int64, task SecureChangeTask, reason string) error {
+	update := SecureChangeTask{ID: task.ID, Status: "DONE"}
+	for _, f := range task.Fields.Field {
+		if field, ok := f.(SecureChangeApproveRejectField); ok {
+			field.Approved = true
+			field.Reason = reason
+			update.Fields.Field = append(update.Fields.Field, field)
+		}
+	}
+
+	response, err := c.R().
+		SetBody(SecureChangeTaskRequest{Task: update}).
+		Put(fmt.Sprintf("/securechange/tickets/%d/steps/%d/tasks/%d", id, stepID, task.ID))
+	if err != nil {
+		return err
+	}
+
+	switch response.StatusCode() {
+	case 200, 204:
+		return nil
+	case 401:
+		return fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return fmt.Errorf("%s", response.String())
+	}
+}
+
+// containsStep reports whether a step name is one of the given names, ignoring case
+func containsStep(steps []string, name string) bool {
+	for _, step := range steps {
+		if strings.EqualFold(step, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// modifyRules opens a ticket applying the given modifications to existing rules
+func modifyRules(ctx context.Context, c *tufinclient.SecureChangeClient, workflow SecureChangeWorkflow, subject string, modifications []SecureChangeRuleModification, opts ticketOptions) (int64, error) {
+	for i := range modifications {
+		modifications[i].XsiType = "modify_rule_modification"
+	}
+	ticket := singleStepTicket(subject, workflow, "Submit rule modification request",
+		SecureChangeRuleModificationField{
+			XsiType: "rule_modification_field",
+			Name:    "Rule Modification",
+			RuleModifications: SecureChangeRuleModifications{
+				RuleModification: modifications,
+			},
+		},
+	)
+	return submitTicket(ctx, c, ticket, opts, "")
+}
+
+// decommissionRules opens a ticket to DISABLE or REMOVE the given rules, grouped by device and binding
+func decommissionRules(ctx context.Context, c *tufinclient.SecureChangeClient, workflow SecureChangeWorkflow, subject string, action string, devices []SecureChangeDecommissionDevice, opts ticketOptions) (int64, error) {
+	ticket := singleStepTicket(subject, workflow, "Submit rule decommission request",
+		SecureChangeRuleDecommissionField{
+			XsiType: "rule_decommission",
+			Name:    "Rule Decommission",
+			Action:  action,
+			Devices: SecureChangeDecommissionDevices{Device: devices},
+		},
+	)
+	return submitTicket(ctx, c, ticket, opts, "")
+}
@@ -0,0 +1,600 @@
+package tufin
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// SecureChangeTicketRequest wraps a ticket sent to or returned by SecureChange
+type SecureChangeTicketRequest struct {
+	Ticket SecureChangeTicket `json:"ticket"`
+}
+
+// SecureChangeTicket represents a ticket within SecureChange whose tasks may carry any field type
+type SecureChangeTicket struct {
+	ID          int64                    `json:"id,omitempty"`
+	Comments    *SecureChangeComments    `json:"comments,omitempty"`
+	CurrentStep *SecureChangeCurrentStep `json:"current_step,omitempty"`
+	Priority    string                   `json:"priority"`
+	Requester   string                   `json:"requester,omitempty"`
+	Status      string                   `json:"status,omitempty"`
+	Steps       SecureChangeSteps        `json:"steps"`
+	Subject     string                   `json:"subject"`
+	Workflow    SecureChangeWorkflow     `json:"workflow"`
+}
+
+// SecureChangeComments represents the comments on a SecureChange ticket
+type SecureChangeComments struct {
+	Comment []SecureChangeComment `json:"comment"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-comment array
+func (c *SecureChangeComments) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Comment json.RawMessage `json:"comment"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalList(raw.Comment, &c.Comment)
+}
+
+// SecureChangeComment represents a single comment on a SecureChange ticket
+type SecureChangeComment struct {
+	Content string `json:"content"`
+	Type    string `json:"type,omitempty"`
+	User    string `json:"user,omitempty"`
+}
+
+// SecureChangeCurrentStep represents the step an open SecureChange ticket is waiting on
+type SecureChangeCurrentStep struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// SecureChangeWorkflow represents the workflow field in a SecureChange ticket, which may be referenced by name alone
+type SecureChangeWorkflow struct {
+	ID   int64  `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// SecureChangeSteps represents the steps in a SecureChange ticket
+type SecureChangeSteps struct {
+	Step []SecureChangeStep `json:"step"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-step array
+func (s *SecureChangeSteps) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Step json.RawMessage `json:"step"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalList(raw.Step, &s.Step)
+}
+
+// SecureChangeStep represents a single step in a SecureChange ticket
+type SecureChangeStep struct {
+	ID      int64             `json:"id,omitempty"`
+	Name    string            `json:"name"`
+	Redone  bool              `json:"redone,omitempty"`
+	Skipped bool              `json:"skipped,omitempty"`
+	Tasks   SecureChangeTasks `json:"tasks"`
+}
+
+// SecureChangeTasks represents tasks in a SecureChange ticket
+type SecureChangeTasks struct {
+	Task []SecureChangeTask `json:"task"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-task array
+func (t *SecureChangeTasks) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Task json.RawMessage `json:"task"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalList(raw.Task, &t.Task)
+}
+
+// SecureChangeRejectRequest is the request body used to reject a SecureChange ticket
+type SecureChangeRejectRequest struct {
+	RejectComment SecureChangeRejectComment `json:"reject_comment"`
+}
+
+// SecureChangeRejectComment carries the reason a SecureChange ticket is rejected
+type SecureChangeRejectComment struct {
+	Comment string `json:"comment"`
+}
+
+// SecureChangeTaskRequest is the request body used to update a SecureChange task
+type SecureChangeTaskRequest struct {
+	Task SecureChangeTask `json:"task"`
+}
+
+// SecureChangeTask represents a single task in a SecureChange ticket
+type SecureChangeTask struct {
+	ID       int64              `json:"id,omitempty"`
+	Assignee string             `json:"assignee,omitempty"`
+	Fields   SecureChangeFields `json:"fields"`
+	Status   string             `json:"status,omitempty"`
+}
+
+// SecureChangeFields represents fields within a SecureChange ticket, each of which marshals its own @xsi.type
+type SecureChangeFields struct {
+	Field []interface{} `json:"field"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-field array, and decodes each
+// field into its typed struct according to @xsi.type
+func (f *SecureChangeFields) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Field json.RawMessage `json:"field"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var fields []json.RawMessage
+	if err := unmarshalList(raw.Field, &fields); err != nil {
+		return err
+	}
+	f.Field = make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		decoded, err := unmarshalField(field)
+		if err != nil {
+			return err
+		}
+		f.Field = append(f.Field, decoded)
+	}
+	return nil
+}
+
+// unmarshalField decodes a single ticket field into the struct matching its @xsi.type. Field types without a
+// dedicated struct are kept as generic maps.
+func unmarshalField(data json.RawMessage) (interface{}, error) {
+	var header struct {
+		XsiType string `json:"@xsi.type"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+
+	switch header.XsiType {
+	case "text_field", "text_area":
+		var field SecureChangeTextField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	case "drop_down_list":
+		var field SecureChangeDropDownField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	case "date":
+		var field SecureChangeDateField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	case "checkbox":
+		var field SecureChangeCheckboxField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	case "multiple_selection":
+		var field SecureChangeMultipleSelectionField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	case "approve_reject":
+		var field SecureChangeApproveRejectField
+		err := json.Unmarshal(data, &field)
+		return field, err
+	default:
+		var field map[string]interface{}
+		err := json.Unmarshal(data, &field)
+		return field, err
+	}
+}
+
+// SecureChangeTextField represents a text_field or text_area field within a SecureChange ticket
+type SecureChangeTextField struct {
+	XsiType string `json:"@xsi.type"`
+	Name    string `json:"name"`
+	Text    string `json:"text"`
+}
+
+// SecureChangeDropDownField represents a drop_down_list field within a SecureChange ticket
+type SecureChangeDropDownField struct {
+	XsiType   string `json:"@xsi.type"`
+	Name      string `json:"name"`
+	Selection string `json:"selection"`
+}
+
+// SecureChangeDateField represents a date field within a SecureChange ticket
+type SecureChangeDateField struct {
+	XsiType string `json:"@xsi.type"`
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+}
+
+// SecureChangeCheckboxField represents a checkbox field within a SecureChange ticket
+type SecureChangeCheckboxField struct {
+	XsiType string `json:"@xsi.type"`
+	Name    string `json:"name"`
+	Value   bool   `json:"value"`
+}
+
+// SecureChangeMultipleSelectionField represents a multiple_selection field within a SecureChange ticket
+type SecureChangeMultipleSelectionField struct {
+	XsiType         string                      `json:"@xsi.type"`
+	Name            string                      `json:"name"`
+	SelectedOptions SecureChangeSelectedOptions `json:"selected_options"`
+}
+
+// SecureChangeApproveRejectField represents the approve_reject field of an approval step within a SecureChange ticket
+type SecureChangeApproveRejectField struct {
+	XsiType  string `json:"@xsi.type"`
+	ID       int64  `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Approved bool   `json:"approved"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// SecureChangeSelectedOptions represents the options chosen in a multiple_selection field
+type SecureChangeSelectedOptions struct {
+	SelectedOption []SecureChangeSelectedOption `json:"selected_option"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-option array
+func (o *SecureChangeSelectedOptions) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		SelectedOption json.RawMessage `json:"selected_option"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalList(raw.SelectedOption, &o.SelectedOption)
+}
+
+// SecureChangeSelectedOption represents a single option chosen in a multiple_selection field
+type SecureChangeSelectedOption struct {
+	Value string `json:"value"`
+}
+
+// SecureChangeGroupChangeField represents a multi_group_change field within a SecureChange ticket
+type SecureChangeGroupChangeField struct {
+	XsiType     string                    `json:"@xsi.type"`
+	GroupChange []SecureChangeGroupChange `json:"group_change"`
+	Name        string                    `json:"name"`
+}
+
+// SecureChangeGroupChange represents group_change object within a SecureChange ticket
+type SecureChangeGroupChange struct {
+	XsiType        string                   `json:"@xsi.type"`
+	ChangeAction   string                   `json:"change_action"`
+	ManagementID   int64                    `json:"management_id"`
+	ManagementName string                   `json:"management_name,omitempty"`
+	Members        SecureChangeGroupMembers `json:"members"`
+	Name           string                   `json:"name"`
+}
+
+// SecureChangeGroupMembers represents a collection of members for a group_change
+type SecureChangeGroupMembers struct {
+	Member []SecureChangeGroupMember `json:"member"`
+}
+
+// SecureChangeGroupMember represents a single member for a group_change, including the comment given to new objects
+type SecureChangeGroupMember struct {
+	tufinclient.SecureChangeGroupMember
+	Comment string `json:"comment,omitempty"`
+}
+
+// SecureChangeNetworkObjectChangeField represents a multi_network_object_change field within a SecureChange ticket
+type SecureChangeNetworkObjectChangeField struct {
+	XsiType             string                            `json:"@xsi.type"`
+	Name                string                            `json:"name"`
+	NetworkObjectChange []SecureChangeNetworkObjectChange `json:"network_object_change"`
+}
+
+// SecureChangeNetworkObjectChange represents a single standalone network object change on a device
+type SecureChangeNetworkObjectChange struct {
+	XsiType       string `json:"@xsi.type"`
+	ChangeAction  string `json:"change_action"`
+	ManagementID  int64  `json:"management_id"`
+	Name          string `json:"name"`
+	ObjectType    string `json:"object_type"`
+	ObjectDetails string `json:"object_details,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// SecureChangeServiceObjectChangeField represents a multi_service_object_change field within a SecureChange ticket
+type SecureChangeServiceObjectChangeField struct {
+	XsiType             string                            `json:"@xsi.type"`
+	Name                string                            `json:"name"`
+	ServiceObjectChange []SecureChangeServiceObjectChange `json:"service_object_change"`
+}
+
+// SecureChangeServiceObjectChange represents a single service object change on a device
+type SecureChangeServiceObjectChange struct {
+	XsiType       string `json:"@xsi.type"`
+	ChangeAction  string `json:"change_action"`
+	ManagementID  int64  `json:"management_id"`
+	Name          string `json:"name"`
+	ObjectType    string `json:"object_type"`
+	ObjectDetails string `json:"object_details,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+}
+
+// SecureChangeServiceGroupChangeField represents a multi_service_group_change field within a SecureChange ticket
+type SecureChangeServiceGroupChangeField struct {
+	XsiType     string                           `json:"@xsi.type"`
+	GroupChange []SecureChangeServiceGroupChange `json:"service_group_change"`
+	Name        string                           `json:"name"`
+}
+
+// SecureChangeServiceGroupChange represents a service_group_change object within a SecureChange ticket
+type SecureChangeServiceGroupChange struct {
+	XsiType      string                          `json:"@xsi.type"`
+	ChangeAction string                          `json:"change_action"`
+	ManagementID int64                           `json:"management_id"`
+	Members      SecureChangeServiceGroupMembers `json:"members"`
+	Name         string                          `json:"name"`
+}
+
+// SecureChangeServiceGroupMembers represents a collection of members for a service_group_change
+type SecureChangeServiceGroupMembers struct {
+	Member []SecureChangeServiceGroupMember `json:"member"`
+}
+
+// SecureChangeServiceGroupMember represents a single existing service added to or removed from a service group
+type SecureChangeServiceGroupMember struct {
+	Type                string `json:"@type"`
+	XsiType             string `json:"@xsi.type"`
+	Name                string `json:"name"`
+	ObjectUpdatedStatus string `json:"object_updated_status,omitempty"`
+	Status              string `json:"status"`
+}
+
+// SecureChangeAccessRequestField represents a multi_access_request field within a SecureChange ticket
+type SecureChangeAccessRequestField struct {
+	XsiType       string                      `json:"@xsi.type"`
+	Name          string                      `json:"name"`
+	AccessRequest []SecureChangeAccessRequest `json:"access_request"`
+}
+
+// SecureChangeAccessRequest represents a single access request (flow) within a multi_access_request field
+type SecureChangeAccessRequest struct {
+	Order        string                   `json:"order"`
+	Action       string                   `json:"action"`
+	Comment      string                   `json:"comment,omitempty"`
+	Destinations SecureChangeDestinations `json:"destinations"`
+	Services     SecureChangeServices     `json:"services"`
+	Sources      SecureChangeSources      `json:"sources"`
+	Targets      *SecureChangeTargets     `json:"targets,omitempty"`
+	UseTopology  bool                     `json:"use_topology"`
+}
+
+// SecureChangeSources represents the sources of an access request
+type SecureChangeSources struct {
+	Source []SecureChangeEndpoint `json:"source"`
+}
+
+// SecureChangeDestinations represents the destinations of an access request
+type SecureChangeDestinations struct {
+	Destination []SecureChangeEndpoint `json:"destination"`
+}
+
+// SecureChangeEndpoint represents a single source or destination of an access request
+type SecureChangeEndpoint struct {
+	Type           string `json:"@type"`
+	Cidr           int    `json:"cidr,omitempty"`
+	IPAddress      string `json:"ip_address,omitempty"`
+	Netmask        string `json:"netmask,omitempty"`
+	RangeFirstIP   string `json:"range_first_ip,omitempty"`
+	RangeLastIP    string `json:"range_last_ip,omitempty"`
+	ObjectName     string `json:"object_name,omitempty"`
+	ManagementName string `json:"management_name,omitempty"`
+}
+
+// SecureChangeServices represents the services of an access request
+type SecureChangeServices struct {
+	Service []SecureChangeService `json:"service"`
+}
+
+// SecureChangeService represents a single service of an access request
+type SecureChangeService struct {
+	Type       string `json:"@type"`
+	Protocol   string `json:"protocol,omitempty"`
+	Port       string `json:"port,omitempty"`
+	ObjectName string `json:"object_name,omitempty"`
+}
+
+// SecureChangeTargets represents the devices an access request is restricted to
+type SecureChangeTargets struct {
+	Target []SecureChangeTarget `json:"target"`
+}
+
+// SecureChangeTarget represents a single device targeted by an access request
+type SecureChangeTarget struct {
+	Type           string `json:"@type"`
+	ObjectName     string `json:"object_name"`
+	ManagementName string `json:"management_name"`
+}
+
+// unmarshalList decodes a JSON value that SecureChange renders as an object when it holds a single item and as
+// an array otherwise
+func unmarshalList(data json.RawMessage, list interface{}) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if data[0] == '{' {
+		data = append(append([]byte("["), data...), ']')
+	}
+	return json.Unmarshal(data, list)
+}
+
+// SecureChangeTicketSearchResult represents the tickets matched by a SecureChange ticket search
+type SecureChangeTicketSearchResult struct {
+	TicketsSearchResults SecureChangeTicketSearchResults `json:"tickets_search_results"`
+}
+
+// SecureChangeTicketSearchResults represents a collection of ticket search matches
+type SecureChangeTicketSearchResults struct {
+	TicketResult []SecureChangeTicketSummary `json:"ticket_result"`
+}
+
+// UnmarshalJSON accepts the single object SecureChange returns in place of a one-ticket array
+func (r *SecureChangeTicketSearchResults) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		TicketResult json.RawMessage `json:"ticket_result"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	return unmarshalList(raw.TicketResult, &r.TicketResult)
+}
+
+// SecureChangeTicketSummary represents a single ticket matched by a SecureChange ticket search
+type SecureChangeTicketSummary struct {
+	ID           int64  `json:"id"`
+	CreateDate   string `json:"create_date"`
+	CurrentStep  string `json:"current_step"`
+	Domain       string `json:"domain"`
+	Priority     string `json:"priority"`
+	Requester    string `json:"requester_name"`
+	Status       string `json:"status"`
+	Subject      string `json:"subject"`
+	UpdateDate   string `json:"update_date"`
+	WorkflowName string `json:"workflow_name"`
+}
+
+// SecureChangeTaskCommentRequest wraps a comment added to a ticket task
+type SecureChangeTaskCommentRequest struct {
+	Comment SecureChangeTaskComment `json:"comment"`
+}
+
+// SecureChangeTaskComment represents a comment, with optional uploaded attachments, added to a ticket task
+type SecureChangeTaskComment struct {
+	Content     string                      `json:"content"`
+	Attachments *SecureChangeAttachmentRefs `json:"attachments,omitempty"`
+}
+
+// SecureChangeAttachmentRefs represents attachments previously uploaded to SecureChange
+type SecureChangeAttachmentRefs struct {
+	Attachment []SecureChangeAttachmentRef `json:"attachment"`
+}
+
+// SecureChangeAttachmentRef references an uploaded attachment by the UID SecureChange returned for it
+type SecureChangeAttachmentRef struct {
+	UID string `json:"uid"`
+}
+
+// SecureChangeSecurityPolicyViolation represents a single violation of the security zone matrix
+type SecureChangeSecurityPolicyViolation struct {
+	Severity            string                       `json:"severity"`
+	Description         string                       `json:"violation_description,omitempty"`
+	SecurityZoneMatrix  SecureChangeSecurityZoneRef  `json:"security_zone_matrix"`
+	MatrixCellViolation SecureChangeMatrixCellResult `json:"matrix_cell_violation"`
+}
+
+// SecureChangeSecurityZoneRef references a security zone matrix by name
+type SecureChangeSecurityZoneRef struct {
+	Name string `json:"name"`
+}
+
+// SecureChangeMatrixCellResult identifies the zone matrix cell a violation was found in
+type SecureChangeMatrixCellResult struct {
+	FromZone string `json:"from_zone"`
+	ToZone   string `json:"to_zone"`
+}
+
+// SecureChangeRuleModificationField represents a rule_modification_field within a SecureChange ticket
+type SecureChangeRuleModificationField struct {
+	XsiType           string                        `json:"@xsi.type"`
+	Name              string                        `json:"name"`
+	RuleModifications SecureChangeRuleModifications `json:"rule_modifications"`
+}
+
+// SecureChangeRuleModifications represents the rule modifications requested in a rule_modification_field
+type SecureChangeRuleModifications struct {
+	RuleModification []SecureChangeRuleModification `json:"rule_modification"`
+}
+
+// SecureChangeRuleModification represents the changes requested to a single existing rule
+type SecureChangeRuleModification struct {
+	XsiType                  string                           `json:"@xsi.type"`
+	DeviceID                 int64                            `json:"device_id"`
+	RuleKey                  SecureChangeRuleKey              `json:"rule_key"`
+	SourceModifications      *SecureChangeObjectModifications `json:"source_modifications,omitempty"`
+	DestinationModifications *SecureChangeObjectModifications `json:"destination_modifications,omitempty"`
+	ServiceModifications     *SecureChangeObjectModifications `json:"service_modifications,omitempty"`
+}
+
+// SecureChangeRuleKey identifies an existing rule on a device
+type SecureChangeRuleKey struct {
+	DeviceID   int64  `json:"device_id"`
+	BindingUID string `json:"binding_uid"`
+	RuleUID    string `json:"rule_uid"`
+}
+
+// SecureChangeObjectModifications represents the objects added to and removed from one side of a rule
+type SecureChangeObjectModifications struct {
+	AddObjects    *SecureChangeModificationObjects `json:"add_objects,omitempty"`
+	RemoveObjects *SecureChangeModificationObjects `json:"remove_objects,omitempty"`
+}
+
+// SecureChangeModificationObjects represents network objects or services in a rule modification
+type SecureChangeModificationObjects struct {
+	NetworkObject []SecureChangeModificationObject `json:"network_object,omitempty"`
+	Service       []SecureChangeModificationObject `json:"service,omitempty"`
+}
+
+// SecureChangeModificationObject represents a single network object or service added to or removed from a rule.
+// Existing objects are referenced by UID, while new ones carry their address or port.
+type SecureChangeModificationObject struct {
+	XsiType  string `json:"@xsi.type"`
+	Name     string `json:"name"`
+	UID      string `json:"uid,omitempty"`
+	IP       string `json:"ip,omitempty"`
+	Netmask  string `json:"netmask,omitempty"`
+	FirstIP  string `json:"first_ip,omitempty"`
+	LastIP   string `json:"last_ip,omitempty"`
+	Protocol int    `json:"protocol,omitempty"`
+	Min      int    `json:"min,omitempty"`
+	Max      int    `json:"max,omitempty"`
+}
+
+// SecureChangeRuleDecommissionField represents a rule_decommission field within a SecureChange ticket
+type SecureChangeRuleDecommissionField struct {
+	XsiType string                          `json:"@xsi.type"`
+	Name    string                          `json:"name"`
+	Action  string                          `json:"action"`
+	Devices SecureChangeDecommissionDevices `json:"devices"`
+}
+
+// SecureChangeDecommissionDevices represents the devices whose rules a rule_decommission field targets
+type SecureChangeDecommissionDevices struct {
+	Device []SecureChangeDecommissionDevice `json:"device"`
+}
+
+// SecureChangeDecommissionDevice represents the rules to decommission on a single device, grouped by binding
+type SecureChangeDecommissionDevice struct {
+	ManagementID int64                            `json:"management_id"`
+	Bindings     SecureChangeDecommissionBindings `json:"bindings"`
+}
+
+// SecureChangeDecommissionBindings represents the bindings holding rules to decommission
+type SecureChangeDecommissionBindings struct {
+	Binding []SecureChangeDecommissionBinding `json:"binding"`
+}
+
+// SecureChangeDecommissionBinding represents the rules to decommission within a single binding
+type SecureChangeDecommissionBinding struct {
+	BindingUID string                          `json:"binding_uid"`
+	RuleIDs    SecureChangeDecommissionRuleIDs `json:"rule_ids"`
+}
+
+// SecureChangeDecommissionRuleIDs lists the SecureTrack IDs of rules to decommission
+type SecureChangeDecommissionRuleIDs struct {
+	ID []int64 `json:"id"`
+}
@@ -0,0 +1,408 @@
+package tufin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// getDeviceNetworkObjectByUID searches a SecureTrack device for a network_object by its UID
+func getDeviceNetworkObjectByUID(c *tufinclient.SecureTrackClient, uid string, deviceID string) (*tufinclient.SecureTrackNetworkObject, error) {
+	response, err := c.R().
+		SetResult(&tufinclient.SecureTrackNetworkObjectsResult{}).
+		SetQueryParams(map[string]string{
+			"filter":    "uid",
+			"uid":       uid,
+			"device_id": deviceID,
+		}).
+		SetHeader("Accept", "application/json").
+		Get("/network_objects/search.json")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		objs := response.Result().(*tufinclient.SecureTrackNetworkObjectsResult)
+		if objs.NetworkObjects.Count == 0 {
+			return nil, nil
+		}
+		if objs.NetworkObjects.Count == 1 {
+			return &objs.NetworkObjects.NetworkObject[0], nil
+		}
+		return nil, fmt.Errorf("Multiple network objects found for uid %s on device %s", uid, deviceID)
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getDeviceRangeObjectByUID retrieves the bounds of an address range object on a SecureTrack device by its UID
+func getDeviceRangeObjectByUID(c *tufinclient.SecureTrackClient, uid string, deviceID string) (*SecureTrackRangeObject, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackRangeObjectsResult{}).
+		SetQueryParams(map[string]string{
+			"filter":    "uid",
+			"uid":       uid,
+			"device_id": deviceID,
+		}).
+		SetHeader("Accept", "application/json").
+		Get("/network_objects/search.json")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		objs := response.Result().(*SecureTrackRangeObjectsResult)
+		if len(objs.NetworkObjects.NetworkObject) == 0 {
+			return nil, nil
+		}
+		return &objs.NetworkObjects.NetworkObject[0], nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// resolveDeviceIDs looks up each SecureTrack device by name or IP and returns their management IDs
+func resolveDeviceIDs(c *tufinclient.SecureTrackClient, devices []interface{}) ([]int64, error) {
+	ids := make([]int64, 0, len(devices))
+	for _, d := range devices {
+		device, err := c.GetDevice(d.(string))
+		if err != nil {
+			return nil, err
+		}
+		if device == nil {
+			return nil, fmt.Errorf("Device %s does not exist in SecureTrack", d.(string))
+		}
+		id, err := strconv.ParseInt(device.ID, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("Could not convert device id %s to integer", device.ID)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// getDeviceServiceByName searches a SecureTrack device for a service by a specified name
+func getDeviceServiceByName(c *tufinclient.SecureTrackClient, name string, deviceID string) (*SecureTrackService, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackServicesResult{}).
+		SetQueryParams(map[string]string{
+			"filter":      "text",
+			"exact_match": "true",
+			"name":        name,
+			"device_id":   deviceID,
+		}).
+		SetHeader("Accept", "application/json").
+		Get("/services/search.json")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		services := response.Result().(*SecureTrackServicesResult)
+		// exact_match is case insensitive, so only an identically named service counts
+		for i, service := range services.Services.Service {
+			if service.DisplayName == name || service.Name == name {
+				return &services.Services.Service[i], nil
+			}
+		}
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// networkObjectOnAnyDevice reports whether a network object with the given name exists on any of the devices
+func networkObjectOnAnyDevice(c *tufinclient.SecureTrackClient, name string, mgmtIDs []int64) (bool, error) {
+	for _, mgmtID := range mgmtIDs {
+		obj, err := c.GetDeviceNetworkObjectByName(name, strconv.FormatInt(mgmtID, 10), true)
+		if err != nil {
+			return false, err
+		}
+		if obj != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// serviceOnAnyDevice reports whether a service or service group with the given name exists on any of the devices
+func serviceOnAnyDevice(c *tufinclient.SecureTrackClient, name string, mgmtIDs []int64) (bool, error) {
+	for _, mgmtID := range mgmtIDs {
+		service, err := getDeviceServiceByName(c, name, strconv.FormatInt(mgmtID, 10))
+		if err != nil {
+			return false, err
+		}
+		if service != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getTopologyPath queries the SecureTrack topology for the path traffic takes from source to destination
+func getTopologyPath(c *tufinclient.SecureTrackClient, source string, destination string, service string, includeIncomplete bool) (*SecureTrackPathCalcResults, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackTopologyPathResult{}).
+		SetQueryParams(map[string]string{
+			"src":                    source,
+			"dst":                    destination,
+			"service":                service,
+			"includeIncompletePaths": strconv.FormatBool(includeIncomplete),
+		}).
+		SetHeader("Accept", "application/json").
+		Get("/topology/path")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		return &response.Result().(*SecureTrackTopologyPathResult).PathCalcResults, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// rulesPageSize is how many rules are requested per page when listing a device's rules
+const rulesPageSize = 100
+
+// SecureTrackClient adds the rule listing and search calls the provider needs to the tufinclient SecureTrack client
+type SecureTrackClient struct {
+	*tufinclient.SecureTrackClient
+}
+
+// secureTrack wraps a tufinclient SecureTrack client with the provider's rule calls
+func secureTrack(c *tufinclient.SecureTrackClient) SecureTrackClient {
+	return SecureTrackClient{SecureTrackClient: c}
+}
+
+// GetDeviceRules lists every security rule on a device, or in the given revision when it is not empty
+func (c SecureTrackClient) GetDeviceRules(deviceID int64, revision string) ([]SecureTrackRule, error) {
+	url := fmt.Sprintf("/devices/%d/rules", deviceID)
+	if revision != "" {
+		url = fmt.Sprintf("/revisions/%s/rules", revision)
+	}
+	return c.rulePages(url, map[string]string{})
+}
+
+// SearchDeviceRules lists the security rules on a device matching a SecureTrack rule search, from the given revision
+// when it is not empty
+func (c SecureTrackClient) SearchDeviceRules(deviceID int64, revision string, search string) ([]SecureTrackRule, error) {
+	params := map[string]string{"search_text": search}
+	if revision != "" {
+		params["revision_id"] = revision
+	}
+	return c.rulePages(fmt.Sprintf("/rule_search/%d", deviceID), params)
+}
+
+// rulePages follows the API's pagination to collect every rule a rule listing returns
+func (c SecureTrackClient) rulePages(url string, params map[string]string) ([]SecureTrackRule, error) {
+	rules := []SecureTrackRule{}
+	for start := 0; ; start += rulesPageSize {
+		params["start"] = strconv.Itoa(start)
+		params["count"] = strconv.Itoa(rulesPageSize)
+
+		response, err := c.R().
+			SetResult(&SecureTrackRulesResult{}).
+			SetQueryParams(params).
+			SetHeader("Accept", "application/json").
+			Get(url)
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.StatusCode() {
+		case 401:
+			return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+		case 200:
+			page := response.Result().(*SecureTrackRulesResult).Rules
+			rules = append(rules, page.Rule...)
+			// Some endpoints leave total unset, so only a short page reliably marks the end
+			if len(page.Rule) < rulesPageSize || (page.Total > 0 && int64(len(rules)) >= page.Total) {
+				return rules, nil
+			}
+		default:
+			return nil, fmt.Errorf("%s", response.String())
+		}
+	}
+}
+
+// getRuleLastHits returns when each rule on a device was last hit, keyed by rule UID
+func getRuleLastHits(c *tufinclient.SecureTrackClient, deviceID int64) (map[string]string, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackRuleLastUsageResult{}).
+		SetHeader("Accept", "application/json").
+		Get(fmt.Sprintf("/rule_last_usage/find_all/%d", deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		hits := map[string]string{}
+		for _, usage := range response.Result().(*SecureTrackRuleLastUsageResult).RuleLastUsage {
+			hits[usage.RuleUID] = usage.RuleLastHit
+		}
+		return hits, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// runPolicyAnalysis finds the rules matching a flow on the given devices, or on every device when deviceIDs is empty
+func runPolicyAnalysis(c *tufinclient.SecureTrackClient, deviceIDs []int64, sources []string, destinations []string, services []string) ([]SecureTrackDeviceAndBindings, error) {
+	params := map[string]string{
+		"sources":      strings.Join(sources, ","),
+		"destinations": strings.Join(destinations, ","),
+		"services":     strings.Join(services, ","),
+	}
+	if len(deviceIDs) > 0 {
+		ids := make([]string, 0, len(deviceIDs))
+		for _, id := range deviceIDs {
+			ids = append(ids, strconv.FormatInt(id, 10))
+		}
+		params["device_ids"] = strings.Join(ids, ",")
+	}
+
+	response, err := c.R().
+		SetResult(&SecureTrackPolicyAnalysisResult{}).
+		SetQueryParams(params).
+		SetHeader("Accept", "application/json").
+		Get("/policy_analysis/query/matching_rules")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		return response.Result().(*SecureTrackPolicyAnalysisResult).PolicyAnalysisQueryResult.DevicesAndBindings, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// GetDeviceRuleByUID finds a security rule on a device by its UID, returning nil when the device has no such rule
+func (c SecureTrackClient) GetDeviceRuleByUID(deviceID int64, uid string) (*SecureTrackRule, error) {
+	uid = strings.Trim(uid, "{}")
+	rules, err := c.SearchDeviceRules(deviceID, "", "uid:"+uid)
+	if err != nil {
+		return nil, err
+	}
+	// The search matches on text, so only a rule with exactly this UID counts
+	for i, rule := range rules {
+		if strings.EqualFold(strings.Trim(rule.UID, "{}"), uid) {
+			return &rules[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// shadowingBatchSize is how many rule UIDs are sent per shadowing rules query
+const shadowingBatchSize = 50
+
+// getShadowedRules returns the given rules on a device that are fully shadowed by earlier rules
+func getShadowedRules(c *tufinclient.SecureTrackClient, deviceID int64, uids []string) ([]SecureTrackShadowedRule, error) {
+	shadowed := []SecureTrackShadowedRule{}
+	for start := 0; start < len(uids); start += shadowingBatchSize {
+		end := start + shadowingBatchSize
+		if end > len(uids) {
+			end = len(uids)
+		}
+
+		response, err := c.R().
+			SetResult(&SecureTrackShadowingRulesResult{}).
+			SetQueryParam("shadowed_uids", strings.Join(uids[start:end], ",")).
+			SetHeader("Accept", "application/json").
+			Get(fmt.Sprintf("/devices/%d/shadowing_rules", deviceID))
+		if err != nil {
+			return nil, err
+		}
+
+		switch response.StatusCode() {
+		case 401:
+			return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+		case 200:
+			result := response.Result().(*SecureTrackShadowingRulesResult)
+			shadowed = append(shadowed, result.CleanupSet.ShadowedRulesCleanup.ShadowedRules.ShadowedRule...)
+		default:
+			return nil, fmt.Errorf("%s", response.String())
+		}
+	}
+	return shadowed, nil
+}
+
+// getDeviceByID retrieves a SecureTrack device by its ID, returning nil when it does not exist
+func getDeviceByID(c *tufinclient.SecureTrackClient, deviceID string) (*tufinclient.SecureTrackDevice, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackDeviceResult{}).
+		SetHeader("Accept", "application/json").
+		Get("/devices/" + deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return &response.Result().(*SecureTrackDeviceResult).Device, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getDeviceRevisions lists the policy revisions of a device, newest first
+func getDeviceRevisions(c *tufinclient.SecureTrackClient, deviceID int64) ([]SecureTrackRevision, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackRevisionsResult{}).
+		SetHeader("Accept", "application/json").
+		Get(fmt.Sprintf("/devices/%d/revisions", deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		return response.Result().(*SecureTrackRevisionsResult).Revisions.Revision, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// ticketRevision finds the revision of a device that implemented a SecureChange ticket, returning an empty string
+// while no revision lists the ticket
+func ticketRevision(c *tufinclient.SecureTrackClient, deviceID int64, ticketID string) (string, error) {
+	revisions, err := getDeviceRevisions(c, deviceID)
+	if err != nil {
+		return "", err
+	}
+	for _, revision := range revisions {
+		for _, ticket := range revision.Tickets {
+			if ticket.ID == ticketID {
+				return strconv.FormatInt(revision.ID, 10), nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,401 @@
+package tufin
+
+import (
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// SecureTrackServicesResult represents one or more services returned from the API
+type SecureTrackServicesResult struct {
+	Services SecureTrackServices `json:"services"`
+}
+
+// SecureTrackServices represents a collection of services in SecureTrack
+type SecureTrackServices struct {
+	Count   int64                `json:"count"`
+	Service []SecureTrackService `json:"service"`
+	Total   int64                `json:"total"`
+}
+
+// SecureTrackService represents a single SecureTrack service object or service group
+type SecureTrackService struct {
+	XsiType     string                     `json:"@xsi.type"`
+	ClassName   string                     `json:"class_name"`
+	Comment     string                     `json:"comment"`
+	DeviceID    int64                      `json:"device_id"`
+	DisplayName string                     `json:"display_name"`
+	Global      bool                       `json:"global,omitempty"`
+	ID          string                     `json:"id"`
+	Implicit    bool                       `json:"implicit"`
+	Max         int                        `json:"max,omitempty"`
+	Member      []SecureTrackServiceMember `json:"member,omitempty"`
+	Min         int                        `json:"min,omitempty"`
+	Name        string                     `json:"name"`
+	Protocol    int                        `json:"protocol,omitempty"`
+	Type        string                     `json:"type"`
+	UID         string                     `json:"uid"`
+}
+
+// SecureTrackServiceMember represents a member of a SecureTrack service group
+type SecureTrackServiceMember struct {
+	DisplayName string `json:"display_name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	UID         string `json:"uid"`
+}
+
+// SecureTrackTopologyPathResult represents the result of a SecureTrack topology path query
+type SecureTrackTopologyPathResult struct {
+	PathCalcResults SecureTrackPathCalcResults `json:"path_calc_results"`
+}
+
+// SecureTrackPathCalcResults represents the devices a path traverses and whether the traffic is allowed end to end
+type SecureTrackPathCalcResults struct {
+	TrafficAllowed bool                    `json:"traffic_allowed"`
+	DeviceInfo     []SecureTrackPathDevice `json:"device_info"`
+}
+
+// SecureTrackPathDevice represents a single device along a topology path
+type SecureTrackPathDevice struct {
+	ID                 string                      `json:"id"`
+	Name               string                      `json:"name"`
+	Type               string                      `json:"type"`
+	Vendor             string                      `json:"vendor"`
+	IncomingInterfaces []SecureTrackPathInterface  `json:"incomingInterfaces"`
+	NextDevices        []SecureTrackPathNextDevice `json:"nextDevices"`
+	Bindings           []SecureTrackPathBinding    `json:"bindings"`
+}
+
+// SecureTrackPathInterface represents an interface traffic enters a device through
+type SecureTrackPathInterface struct {
+	Name string `json:"name"`
+	IP   string `json:"ip"`
+}
+
+// SecureTrackPathNextDevice represents the next hop from a device along a topology path
+type SecureTrackPathNextDevice struct {
+	Name   string                 `json:"name"`
+	Routes []SecureTrackPathRoute `json:"routes"`
+}
+
+// SecureTrackPathRoute represents the route a device uses towards the next device
+type SecureTrackPathRoute struct {
+	OutgoingInterfaceName string `json:"outgoingInterfaceName"`
+	NextHopIP             string `json:"nextHopIp"`
+}
+
+// SecureTrackPathBinding represents a policy binding on a device and the rules it matched for the traffic
+type SecureTrackPathBinding struct {
+	Name  string                `json:"name"`
+	Rules []SecureTrackPathRule `json:"rules"`
+}
+
+// SecureTrackPathRule represents a rule matched along a topology path
+type SecureTrackPathRule struct {
+	// RuleIdentifier is numeric on some vendors and a string on others
+	RuleIdentifier interface{} `json:"ruleIdentifier"`
+	Action         string      `json:"action"`
+}
+
+// SecureTrackRulesResult represents a page of security rules returned from the API
+type SecureTrackRulesResult struct {
+	Rules SecureTrackRules `json:"rules"`
+}
+
+// SecureTrackRules represents a collection of security rules in SecureTrack
+type SecureTrackRules struct {
+	Count int64             `json:"count"`
+	Rule  []SecureTrackRule `json:"rule"`
+	Total int64             `json:"total"`
+}
+
+// SecureTrackRule represents a single security rule on a SecureTrack device
+type SecureTrackRule struct {
+	ID         int64                   `json:"id"`
+	UID        string                  `json:"uid"`
+	Order      int                     `json:"order"`
+	RuleNumber int                     `json:"rule_number"`
+	Name       string                  `json:"name"`
+	Action     string                  `json:"action"`
+	Comment    string                  `json:"comment"`
+	Disabled   bool                    `json:"disabled"`
+	SrcNetwork []SecureTrackRuleObject `json:"src_network"`
+	DstNetwork []SecureTrackRuleObject `json:"dst_network"`
+	DstService []SecureTrackRuleObject `json:"dst_service"`
+	Binding    SecureTrackRuleBinding  `json:"binding"`
+}
+
+// SecureTrackRuleBinding identifies the policy binding a security rule belongs to
+type SecureTrackRuleBinding struct {
+	BindingUID string `json:"binding_uid"`
+}
+
+// SecureTrackRuleObject represents a network object or service referenced by a security rule
+type SecureTrackRuleObject struct {
+	XsiType     string `json:"@xsi.type"`
+	DisplayName string `json:"display_name"`
+	ID          string `json:"id"`
+	IP          string `json:"ip,omitempty"`
+	Name        string `json:"name"`
+	Netmask     string `json:"netmask,omitempty"`
+	UID         string `json:"uid"`
+}
+
+// SecureTrackRuleLastUsageResult represents the last hit of each rule on a device
+type SecureTrackRuleLastUsageResult struct {
+	RuleLastUsage []SecureTrackRuleLastUsage `json:"rule_last_usage"`
+}
+
+// SecureTrackRuleLastUsage represents when a single rule was last hit
+type SecureTrackRuleLastUsage struct {
+	RuleUID     string `json:"rule_uid"`
+	RuleLastHit string `json:"rule_last_hit"`
+}
+
+// SecureTrackPolicyAnalysisResult represents the result of a SecureTrack policy analysis query
+type SecureTrackPolicyAnalysisResult struct {
+	PolicyAnalysisQueryResult SecureTrackPolicyAnalysisQueryResult `json:"policy_analysis_query_result"`
+}
+
+// SecureTrackPolicyAnalysisQueryResult represents the rules matching a policy analysis query on each device
+type SecureTrackPolicyAnalysisQueryResult struct {
+	DevicesAndBindings []SecureTrackDeviceAndBindings `json:"devices_and_bindings"`
+}
+
+// SecureTrackDeviceAndBindings represents the bindings and matching rules of a single device in a policy analysis
+type SecureTrackDeviceAndBindings struct {
+	Device           tufinclient.SecureTrackDevice `json:"device"`
+	BindingsAndRules []SecureTrackBindingAndRules  `json:"bindings_and_rules"`
+}
+
+// SecureTrackBindingAndRules represents the rules of a single policy binding matching a policy analysis query, in
+// the order the device evaluates them
+type SecureTrackBindingAndRules struct {
+	Binding SecureTrackBinding `json:"binding"`
+	Rules   []SecureTrackRule  `json:"rules"`
+}
+
+// SecureTrackBinding represents a policy binding on a SecureTrack device
+type SecureTrackBinding struct {
+	Name   string `json:"name"`
+	UID    string `json:"uid"`
+	Policy struct {
+		Name string `json:"name"`
+	} `json:"policy"`
+}
+
+// SecureTrackShadowingRulesResult represents the result of a SecureTrack shadowing rules query
+type SecureTrackShadowingRulesResult struct {
+	CleanupSet struct {
+		ShadowedRulesCleanup struct {
+			ShadowedRules struct {
+				ShadowedRule []SecureTrackShadowedRule `json:"shadowed_rule"`
+			} `json:"shadowed_rules"`
+		} `json:"shadowed_rules_cleanup"`
+	} `json:"cleanup_set"`
+}
+
+// SecureTrackShadowedRule represents a rule together with the earlier rules that fully shadow it
+type SecureTrackShadowedRule struct {
+	Rule           SecureTrackRule `json:"rule"`
+	ShadowingRules struct {
+		Rule []SecureTrackRule `json:"rule"`
+	} `json:"shadowing_rules"`
+}
+
+// SecureTrackDeviceResult represents a single device returned from the API
+type SecureTrackDeviceResult struct {
+	Device tufinclient.SecureTrackDevice `json:"device"`
+}
+
+// SecureTrackRevisionsResult represents the revisions of a device returned from the API
+type SecureTrackRevisionsResult struct {
+	Revisions SecureTrackRevisions `json:"revisions"`
+}
+
+// SecureTrackRevisions represents a collection of device revisions in SecureTrack
+type SecureTrackRevisions struct {
+	Revision []SecureTrackRevision `json:"revision"`
+}
+
+// SecureTrackRevision represents a single policy revision of a SecureTrack device
+type SecureTrackRevision struct {
+	ID            int64                       `json:"id"`
+	RevisionID    int64                       `json:"revisionId"`
+	Action        string                      `json:"action"`
+	Date          string                      `json:"date"`
+	Time          string                      `json:"time"`
+	Admin         string                      `json:"admin"`
+	GuiClient     string                      `json:"guiClient"`
+	AuditLog      string                      `json:"auditLog"`
+	PolicyPackage string                      `json:"policyPackage"`
+	Ready         bool                        `json:"ready"`
+	Tickets       []SecureTrackRevisionTicket `json:"tickets"`
+}
+
+// SecureTrackRevisionTicket links a revision to the SecureChange ticket it implemented
+type SecureTrackRevisionTicket struct {
+	ID string `json:"id"`
+}
+
+// SecureTrackZonesResult represents the zones returned from the API
+type SecureTrackZonesResult struct {
+	Zones SecureTrackZones `json:"zones"`
+}
+
+// SecureTrackZones represents a collection of zones in SecureTrack
+type SecureTrackZones struct {
+	Zone []SecureTrackZone `json:"zone"`
+}
+
+// SecureTrackZoneRequest is the request and response body for a single zone
+type SecureTrackZoneRequest struct {
+	Zone SecureTrackZone `json:"zone"`
+}
+
+// SecureTrackZone represents a single SecureTrack zone
+type SecureTrackZone struct {
+	ID       int64  `json:"id,omitempty"`
+	Name     string `json:"name"`
+	Comment  string `json:"comment"`
+	Shared   bool   `json:"shared"`
+	Internet bool   `json:"internet"`
+}
+
+// SecureTrackZoneEntriesResult represents the entries of a zone returned from the API
+type SecureTrackZoneEntriesResult struct {
+	ZoneEntries SecureTrackZoneEntries `json:"zone_entries"`
+}
+
+// SecureTrackZoneEntries represents a collection of zone entries in SecureTrack
+type SecureTrackZoneEntries struct {
+	ZoneEntry []SecureTrackZoneEntry `json:"zone_entry"`
+}
+
+// SecureTrackZoneEntryRequest is the request and response body for a single zone entry
+type SecureTrackZoneEntryRequest struct {
+	ZoneEntry SecureTrackZoneEntry `json:"zone_entry"`
+}
+
+// SecureTrackZoneEntry represents a subnet classified into a SecureTrack zone. IPv4 entries carry a netmask and
+// IPv6 entries a prefix length.
+type SecureTrackZoneEntry struct {
+	ID      int64  `json:"id,omitempty"`
+	IP      string `json:"ip"`
+	Netmask string `json:"netmask,omitempty"`
+	Prefix  int    `json:"prefix,omitempty"`
+	Comment string `json:"comment"`
+	ZoneID  int64  `json:"zoneId,omitempty"`
+}
+
+// SecureTrackDeviceViolationsResult represents the USP violations of a device's rules
+type SecureTrackDeviceViolationsResult struct {
+	SecurityPolicyDeviceViolations struct {
+		ViolatingRules struct {
+			ViolatingRule []SecureTrackViolatingRule `json:"violating_rule"`
+		} `json:"violating_rules"`
+	} `json:"security_policy_device_violations"`
+}
+
+// SecureTrackViolatingRule represents a device rule together with the USP violations it causes
+type SecureTrackViolatingRule struct {
+	UID        string `json:"uid"`
+	RuleNumber int    `json:"rule_number"`
+	Violations struct {
+		Violation []SecureTrackUSPViolation `json:"violation"`
+	} `json:"violations"`
+}
+
+// SecureTrackUSPViolation represents a single violation of a Unified Security Policy matrix cell
+type SecureTrackUSPViolation struct {
+	Severity           string `json:"severity"`
+	SecurityPolicyName string `json:"security_policy_name"`
+	FromZone           string `json:"from_zone"`
+	ToZone             string `json:"to_zone"`
+	Description        string `json:"violation_description"`
+}
+
+// SecureTrackAccessRequestViolationsRequest is the request body used to check proposed access for USP violations
+type SecureTrackAccessRequestViolationsRequest struct {
+	AccessRequests struct {
+		AccessRequest []SecureChangeAccessRequest `json:"access_request"`
+	} `json:"access_requests"`
+}
+
+// SecureTrackAccessRequestViolationsResult represents the USP violations proposed access would introduce
+type SecureTrackAccessRequestViolationsResult struct {
+	AccessRequestViolations struct {
+		AccessRequestViolation []struct {
+			Violations struct {
+				Violation []SecureChangeSecurityPolicyViolation `json:"violation"`
+			} `json:"violations"`
+		} `json:"access_request_violation"`
+	} `json:"access_request_violations"`
+}
+
+// SecureTrackUSPExceptionRequest wraps a USP exception for the create and retrieve requests
+type SecureTrackUSPExceptionRequest struct {
+	Exception SecureTrackUSPException `json:"security_policy_exception"`
+}
+
+// SecureTrackUSPException represents a temporary exception to the Unified Security Policy
+type SecureTrackUSPException struct {
+	ID                  int64  `json:"id,omitempty"`
+	Name                string `json:"name"`
+	ExpirationDate      string `json:"expiration_date"`
+	RequestedBy         string `json:"requested_by"`
+	Description         string `json:"description"`
+	ExemptedTrafficList struct {
+		ExemptedTraffic []SecureTrackExemptedTraffic `json:"exempted_traffic"`
+	} `json:"exempted_traffic_list"`
+}
+
+// SecureTrackExemptedTraffic represents the traffic a USP exception allows
+type SecureTrackExemptedTraffic struct {
+	Source  SecureTrackExemptedNetworks `json:"source"`
+	Dest    SecureTrackExemptedNetworks `json:"dest"`
+	Service SecureTrackExemptedServices `json:"service"`
+}
+
+// SecureTrackExemptedNetworks represents the sources or destinations of exempted traffic
+type SecureTrackExemptedNetworks struct {
+	NetworkItems struct {
+		NetworkItem []SecureTrackExemptedNetworkItem `json:"network_item"`
+	} `json:"network_items"`
+}
+
+// SecureTrackExemptedNetworkItem represents a single subnet or range of exempted traffic
+type SecureTrackExemptedNetworkItem struct {
+	XsiType string `json:"@xsi.type"`
+	IP      string `json:"ip,omitempty"`
+	Netmask string `json:"netmask,omitempty"`
+	FirstIP string `json:"first_ip,omitempty"`
+	LastIP  string `json:"last_ip,omitempty"`
+}
+
+// SecureTrackExemptedServices represents the services of exempted traffic
+type SecureTrackExemptedServices struct {
+	ServiceItems struct {
+		ServiceItem []SecureTrackExemptedServiceItem `json:"service_item"`
+	} `json:"service_items"`
+}
+
+// SecureTrackExemptedServiceItem represents a single protocol and port of exempted traffic
+type SecureTrackExemptedServiceItem struct {
+	XsiType  string `json:"@xsi.type"`
+	Protocol string `json:"protocol"`
+	Port     string `json:"port"`
+}
+
+// SecureTrackRangeObjectsResult represents address range objects returned from a network object search
+type SecureTrackRangeObjectsResult struct {
+	NetworkObjects struct {
+		NetworkObject []SecureTrackRangeObject `json:"network_object"`
+	} `json:"network_objects"`
+}
+
+// SecureTrackRangeObject represents the bounds of an address range object, which the client's network object omits
+type SecureTrackRangeObject struct {
+	UID     string `json:"uid"`
+	FirstIP string `json:"first_ip"`
+	LastIP  string `json:"last_ip"`
+}
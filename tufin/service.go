@@ -0,0 +1,117 @@
+package tufin
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// serviceProtocols maps the supported protocol names onto their IP protocol numbers
+var serviceProtocols = map[string]int{
+	"icmp": 1,
+	"tcp":  6,
+	"udp":  17,
+}
+
+// serviceDefinition represents a TCP/UDP port range or an ICMP type
+type serviceDefinition struct {
+	Protocol string
+	Min      int
+	Max      int
+}
+
+// parseServiceDefinition parses a protocol with either a port or start-end port range, or an ICMP type for icmp
+func parseServiceDefinition(protocol string, port string, icmpType int) (*serviceDefinition, error) {
+	protocol = strings.ToLower(protocol)
+	if _, ok := serviceProtocols[protocol]; !ok {
+		return nil, fmt.Errorf("%s is not a supported protocol", protocol)
+	}
+	if protocol == "icmp" {
+		if port != "" {
+			return nil, fmt.Errorf("icmp services take an icmp_type rather than a port")
+		}
+		if icmpType < 0 || icmpType > 255 {
+			return nil, fmt.Errorf("icmp type %d is out of range", icmpType)
+		}
+		return &serviceDefinition{Protocol: protocol, Min: icmpType, Max: icmpType}, nil
+	}
+
+	bounds := strings.SplitN(port, "-", 2)
+	min, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a valid port or port range", port)
+	}
+	max := min
+	if len(bounds) == 2 {
+		max, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid port or port range", port)
+		}
+	}
+	if min < 1 || max > 65535 || min > max {
+		return nil, fmt.Errorf("%s is not a valid port or port range", port)
+	}
+	return &serviceDefinition{Protocol: protocol, Min: min, Max: max}, nil
+}
+
+// ObjectType returns the SecureChange object_type for the service
+func (s *serviceDefinition) ObjectType() string {
+	return strings.ToUpper(s.Protocol)
+}
+
+// ObjectDetails renders the service as SecureChange object_details, e.g. "tcp 8000-8080"
+func (s *serviceDefinition) ObjectDetails() string {
+	return s.Protocol + " " + s.Port()
+}
+
+// Port renders the port range, or ICMP type, in the notation parseServiceDefinition accepts
+func (s *serviceDefinition) Port() string {
+	if s.Min == s.Max {
+		return strconv.Itoa(s.Min)
+	}
+	return strconv.Itoa(s.Min) + "-" + strconv.Itoa(s.Max)
+}
+
+// serviceProtocolName maps an IP protocol number back onto its supported protocol name
+func serviceProtocolName(protocol int) string {
+	for name, number := range serviceProtocols {
+		if number == protocol {
+			return name
+		}
+	}
+	return ""
+}
+
+// validateServiceProtocol is a schema ValidateFunc for protocol arguments
+func validateServiceProtocol(val interface{}, key string) (warns []string, errs []error) {
+	if _, ok := serviceProtocols[strings.ToLower(val.(string))]; !ok {
+		errs = append(errs, fmt.Errorf("%q must be one of tcp, udp or icmp.", key))
+	}
+	return
+}
+
+// validatePortRange is a schema ValidateFunc for port arguments
+func validatePortRange(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseServiceDefinition("tcp", val.(string), 0); err != nil {
+		errs = append(errs, fmt.Errorf("%q is invalid: %s. May be a single port or a start-end range.", key, err))
+	}
+	return
+}
+
+// validateServiceArguments checks that a tcp or udp service has a port, and that only an icmp service has an icmp_type
+func validateServiceArguments(protocol string, hasPort bool, hasICMPType bool) error {
+	switch strings.ToLower(protocol) {
+	case "icmp":
+		if hasPort {
+			return fmt.Errorf("icmp services take an icmp_type rather than a port")
+		}
+	case "tcp", "udp":
+		if !hasPort {
+			return fmt.Errorf("%s services require a port", strings.ToLower(protocol))
+		}
+		if hasICMPType {
+			return fmt.Errorf("icmp_type can only be set for icmp services")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package tufin
+
+import (
+	"testing"
+)
+
+func TestParseServiceDefinition(t *testing.T) {
+	cases := []struct {
+		protocol      string
+		port          string
+		icmpType      int
+		objectType    string
+		objectDetails string
+		err           bool
+	}{
+		{protocol: "tcp", port: "443", objectType: "TCP", objectDetails: "tcp 443"},
+		{protocol: "TCP", port: "8000-8080", objectType: "TCP", objectDetails: "tcp 8000-8080"},
+		{protocol: "udp", port: " 53 ", objectType: "UDP", objectDetails: "udp 53"},
+		{protocol: "udp", port: "5000 - 5010", objectType: "UDP", objectDetails: "udp 5000-5010"},
+		{protocol: "icmp", icmpType: 8, objectType: "ICMP", objectDetails: "icmp 8"},
+		{protocol: "icmp", icmpType: 0, objectType: "ICMP", objectDetails: "icmp 0"},
+		{protocol: "icmp", port: "8", err: true},
+		{protocol: "icmp", icmpType: 256, err: true},
+		{protocol: "tcp", port: "", err: true},
+		{protocol: "tcp", port: "0", err: true},
+		{protocol: "tcp", port: "65536", err: true},
+		{protocol: "tcp", port: "8080-8000", err: true},
+		{protocol: "tcp", port: "http", err: true},
+		{protocol: "gre", port: "1", err: true},
+	}
+
+	for _, c := range cases {
+		service, err := parseServiceDefinition(c.protocol, c.port, c.icmpType)
+		if c.err {
+			if err == nil {
+				t.Errorf("parseServiceDefinition(%q, %q, %d) succeeded, expected an error", c.protocol, c.port, c.icmpType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseServiceDefinition(%q, %q, %d) returned error: %s", c.protocol, c.port, c.icmpType, err)
+			continue
+		}
+		if service.ObjectType() != c.objectType || service.ObjectDetails() != c.objectDetails {
+			t.Errorf("parseServiceDefinition(%q, %q, %d) = %s %s", c.protocol, c.port, c.icmpType, service.ObjectType(), service.ObjectDetails())
+		}
+	}
+}
+
+func TestValidateServiceArguments(t *testing.T) {
+	cases := []struct {
+		protocol    string
+		hasPort     bool
+		hasICMPType bool
+		valid       bool
+	}{
+		{protocol: "tcp", hasPort: true, valid: true},
+		{protocol: "udp", hasPort: true, valid: true},
+		{protocol: "icmp", hasICMPType: true, valid: true},
+		{protocol: "icmp", valid: true},
+		{protocol: "tcp", valid: false},
+		{protocol: "UDP", valid: false},
+		{protocol: "tcp", hasPort: true, hasICMPType: true, valid: false},
+		{protocol: "icmp", hasPort: true, valid: false},
+	}
+
+	for _, c := range cases {
+		err := validateServiceArguments(c.protocol, c.hasPort, c.hasICMPType)
+		if (err == nil) != c.valid {
+			t.Errorf("validateServiceArguments(%q, %t, %t) returned %v, expected valid = %t", c.protocol, c.hasPort, c.hasICMPType, err, c.valid)
+		}
+	}
+}
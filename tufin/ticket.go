@@ -0,0 +1,222 @@
+package tufin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// ticketFieldTypes lists the generic SecureChange field types tufin_ticket knows how to submit
+var ticketFieldTypes = []string{"text_field", "text_area", "drop_down_list", "date", "checkbox", "multiple_selection"}
+
+// ticketField builds a typed SecureChange field carrying the right @xsi.type for the given field type
+func ticketField(fieldType string, name string, value string, values []string) (interface{}, error) {
+	switch fieldType {
+	case "text_field", "text_area":
+		return SecureChangeTextField{XsiType: fieldType, Name: name, Text: value}, nil
+	case "drop_down_list":
+		return SecureChangeDropDownField{XsiType: fieldType, Name: name, Selection: value}, nil
+	case "date":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("Field %s must be a date in YYYY-MM-DD format, got %q", name, value)
+		}
+		return SecureChangeDateField{XsiType: fieldType, Name: name, Value: value}, nil
+	case "checkbox":
+		switch strings.ToLower(value) {
+		case "true":
+			return SecureChangeCheckboxField{XsiType: fieldType, Name: name, Value: true}, nil
+		case "false", "":
+			return SecureChangeCheckboxField{XsiType: fieldType, Name: name, Value: false}, nil
+		default:
+			return nil, fmt.Errorf("Field %s must be true or false, got %q", name, value)
+		}
+	case "multiple_selection":
+		field := SecureChangeMultipleSelectionField{XsiType: fieldType, Name: name}
+		for _, v := range values {
+			field.SelectedOptions.SelectedOption = append(field.SelectedOptions.SelectedOption, SecureChangeSelectedOption{Value: v})
+		}
+		return field, nil
+	default:
+		return nil, fmt.Errorf("Field %s has unsupported type %s", name, fieldType)
+	}
+}
+
+// validateTicketFieldType is a schema ValidateFunc for generic ticket field types
+func validateTicketFieldType(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	for _, fieldType := range ticketFieldTypes {
+		if v == fieldType {
+			return
+		}
+	}
+	errs = append(errs, fmt.Errorf("%q must be one of %s.", key, strings.Join(ticketFieldTypes, ", ")))
+	return
+}
+
+// validateTicketPriority is a schema ValidateFunc for ticket priorities
+func validateTicketPriority(val interface{}, key string) (warns []string, errs []error) {
+	switch val.(string) {
+	case "Low", "Normal", "High", "Critical":
+	default:
+		errs = append(errs, fmt.Errorf("%q must be one of Low, Normal, High, Critical.", key))
+	}
+	return
+}
+
+// flattenTicketSteps renders a ticket's steps as the step progress exposed by ticket resources and data sources
+func flattenTicketSteps(ticket *SecureChangeTicket) []interface{} {
+	steps := make([]interface{}, 0, len(ticket.Steps.Step))
+	for _, step := range ticket.Steps.Step {
+		status := ""
+		if len(step.Tasks.Task) > 0 {
+			status = step.Tasks.Task[0].Status
+		}
+		if step.Skipped {
+			status = "SKIPPED"
+		}
+		steps = append(steps, map[string]interface{}{
+			"id":     int(step.ID),
+			"name":   step.Name,
+			"status": status,
+			"redone": step.Redone,
+		})
+	}
+	return steps
+}
+
+// ticketStepsSchema describes the computed step progress of a ticket
+func ticketStepsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"status": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"redone": &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+// flattenTicketFields renders every field of every step as name, type and value, joining multiple selections
+// with commas. Structured fields such as access requests are listed with an empty value.
+func flattenTicketFields(ticket *SecureChangeTicket) []interface{} {
+	fields := []interface{}{}
+	for _, step := range ticket.Steps.Step {
+		for _, task := range step.Tasks.Task {
+			for _, f := range task.Fields.Field {
+				var name, fieldType, value string
+				switch field := f.(type) {
+				case SecureChangeTextField:
+					name, fieldType, value = field.Name, field.XsiType, field.Text
+				case SecureChangeDropDownField:
+					name, fieldType, value = field.Name, field.XsiType, field.Selection
+				case SecureChangeDateField:
+					name, fieldType, value = field.Name, field.XsiType, field.Value
+				case SecureChangeCheckboxField:
+					name, fieldType, value = field.Name, field.XsiType, strconv.FormatBool(field.Value)
+				case SecureChangeMultipleSelectionField:
+					selected := []string{}
+					for _, option := range field.SelectedOptions.SelectedOption {
+						selected = append(selected, option.Value)
+					}
+					name, fieldType, value = field.Name, field.XsiType, strings.Join(selected, ",")
+				case SecureChangeApproveRejectField:
+					name, fieldType, value = field.Name, field.XsiType, strconv.FormatBool(field.Approved)
+				case map[string]interface{}:
+					name, _ = field["name"].(string)
+					fieldType, _ = field["@xsi.type"].(string)
+				}
+				fields = append(fields, map[string]interface{}{
+					"step":  step.Name,
+					"name":  name,
+					"type":  fieldType,
+					"value": value,
+				})
+			}
+		}
+	}
+	return fields
+}
+
+// parseTicketDate parses the dates SecureChange reports and the dates accepted by ticket search arguments
+func parseTicketDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05.000-0700", "2006-01-02T15:04:05-0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a recognised date", value)
+}
+
+// ticketDateEnd parses the end of a date range, treating a date without a time as covering that whole day
+func ticketDateEnd(value string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return parseTicketDate(value)
+}
+
+// validateTicketDate is a schema ValidateFunc for date arguments
+func validateTicketDate(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseTicketDate(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be a YYYY-MM-DD or RFC 3339 date.", key))
+	}
+	return
+}
+
+// cancelPendingObjectTicket cancels the last ticket opened for an object resource when it is still open, so
+// destroying the resource cannot be followed by the ticket implementing its change anyway. It reports whether the
+// change never made it onto any device, in which case there is nothing left to delete. onDevices reports whether the
+// change reached at least one device, and is only asked once a ticket was actually cancelled.
+func cancelPendingObjectTicket(c *tufinclient.SecureChangeClient, d *schema.ResourceData, resource string, onDevices func() (bool, error)) (bool, error) {
+	ticketID := int64(d.Get("ticket_id").(int))
+	if ticketID == 0 {
+		return false, nil
+	}
+	cancelled, err := cancelOpenTicket(c, ticketID, fmt.Sprintf("Cancelled by Terraform: %s was destroyed before this ticket was implemented.", resource))
+	if err != nil || !cancelled {
+		return false, err
+	}
+	// A ticket may be cancelled after some of its devices were already changed, and those still need cleaning up
+	present, err := onDevices()
+	if err != nil {
+		return false, err
+	}
+	return !present, nil
+}
+
+// waitForObjectTicket waits for the ticket an object resource opened when wait_for_completion is set. A ticket that is
+// cancelled because the apply was interrupted takes the resource out of state, unless the change already reached a
+// device and so still needs a delete.
+func waitForObjectTicket(ctx context.Context, c *tufinclient.SecureChangeClient, d *schema.ResourceData, ticketID int64, onDevices func() (bool, error)) error {
+	if !d.Get("wait_for_completion").(bool) {
+		return nil
+	}
+	cancelled, err := waitForTicketOrCancel(ctx, c, ticketID)
+	if cancelled {
+		if present, presentErr := onDevices(); presentErr == nil && !present {
+			d.SetId("")
+		}
+	}
+	return err
+}
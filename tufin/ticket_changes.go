@@ -0,0 +1,124 @@
+package tufin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// resourceChange describes the planned change a ticket was opened for
+type resourceChange struct {
+	Resource   string            `json:"resource"`
+	ID         string            `json:"id,omitempty"`
+	Action     string            `json:"action"`
+	Attributes []attributeChange `json:"attributes"`
+}
+
+// attributeChange describes the old and new value of a single resource argument
+type attributeChange struct {
+	Name string      `json:"name"`
+	Old  interface{} `json:"old,omitempty"`
+	New  interface{} `json:"new,omitempty"`
+}
+
+// withChanges records the planned change to the given arguments so it can be commented on the ticket
+func (opts ticketOptions) withChanges(d *schema.ResourceData, resource string, action string, keys ...string) ticketOptions {
+	if !opts.ChangeComments && !opts.ChangeAttachments {
+		return opts
+	}
+	change := resourceChange{Resource: resource, ID: d.Id(), Action: action}
+	for _, key := range keys {
+		old, new := d.GetChange(key)
+		old, new = plainValue(old), plainValue(new)
+		switch action {
+		case "create":
+			old = nil
+		case "delete":
+			new = nil
+		default:
+			if !d.HasChange(key) {
+				continue
+			}
+		}
+		if isEmptyValue(old) && isEmptyValue(new) {
+			continue
+		}
+		change.Attributes = append(change.Attributes, attributeChange{Name: key, Old: old, New: new})
+	}
+	opts.Changes = append(append([]resourceChange{}, opts.Changes...), change)
+	return opts
+}
+
+// String renders a change in the style of a Terraform plan
+func (c resourceChange) String() string {
+	var b strings.Builder
+	symbol := map[string]string{"create": "+", "update": "~", "delete": "-"}[c.Action]
+	fmt.Fprintf(&b, "%s %s (%s)\n", symbol, c.Resource, c.Action)
+	for _, attr := range c.Attributes {
+		switch c.Action {
+		case "create":
+			fmt.Fprintf(&b, "    + %s = %v\n", attr.Name, attr.New)
+		case "delete":
+			fmt.Fprintf(&b, "    - %s = %v\n", attr.Name, attr.Old)
+		default:
+			fmt.Fprintf(&b, "    ~ %s = %v -> %v\n", attr.Name, attr.Old, attr.New)
+		}
+	}
+	return b.String()
+}
+
+// annotateTicket comments the planned changes on a newly opened ticket and, when enabled, attaches them as JSON
+func annotateTicket(c *tufinclient.SecureChangeClient, id int64, opts ticketOptions) error {
+	if len(opts.Changes) == 0 || id == 0 {
+		return nil
+	}
+
+	var attachments []string
+	fileName := fmt.Sprintf("terraform-change-%d.json", id)
+	if opts.ChangeAttachments {
+		summary, err := json.MarshalIndent(opts.Changes, "", "  ")
+		if err != nil {
+			return err
+		}
+		uid, err := uploadAttachment(c, fileName, summary)
+		if err != nil {
+			return err
+		}
+		attachments = append(attachments, uid)
+	}
+
+	// SecureChange only takes attachments on a comment, so without change comments the comment just names the file
+	content := "Terraform change summary attached as " + fileName + "."
+	if opts.ChangeComments {
+		content = "Terraform planned changes:\n"
+		for _, change := range opts.Changes {
+			content += change.String()
+		}
+	}
+	return addTicketComment(c, id, strings.TrimSpace(content), attachments)
+}
+
+// plainValue converts sets into lists so values render and marshal predictably
+func plainValue(v interface{}) interface{} {
+	if set, ok := v.(*schema.Set); ok {
+		return set.List()
+	}
+	return v
+}
+
+// isEmptyValue reports whether a value is the zero value an unset argument reads as
+func isEmptyValue(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,208 @@
+package tufin
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"sync"
+	"text/template"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ticketOptions carries the provider's ticket templates together with a resource's ticket overrides
+type ticketOptions struct {
+	SubjectTemplate *template.Template
+	CommentTemplate *template.Template
+	Justification   string
+	Priority        string
+	Requester       string
+	// AutoAdvanceSteps names the workflow steps the provider completes itself once the ticket is open, approving
+	// them with ApprovalReason
+	AutoAdvanceSteps []string
+	ApprovalReason   string
+	// ChangeComments and ChangeAttachments add the planned Changes to each ticket as a comment and a JSON file
+	ChangeComments    bool
+	ChangeAttachments bool
+	Changes           []resourceChange
+	// Warnings collects the problems met after a ticket was opened, which must not fail the resource
+	Warnings *ticketWarnings
+}
+
+// ticketWarnings collects warnings from ticket submission for the resource that opened the ticket to report
+type ticketWarnings struct {
+	mu    sync.Mutex
+	diags diag.Diagnostics
+}
+
+// add records a warning, and is a no-op on a nil collector
+func (w *ticketWarnings) add(summary string, detail string) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.diags = append(w.diags, diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// Diagnostics returns the warnings collected so far
+func (w *ticketWarnings) Diagnostics() diag.Diagnostics {
+	if w == nil {
+		return nil
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append(diag.Diagnostics{}, w.diags...)
+}
+
+// ticketTemplateData is the data available to ticket_subject_template and ticket_comment_template
+type ticketTemplateData struct {
+	// Subject is the subject the provider would have used without a template
+	Subject       string
+	Justification string
+	Priority      string
+	Requester     string
+	// Workspace is read from TF_WORKSPACE or TFC_WORKSPACE_NAME, so it is empty for a workspace only selected with
+	// terraform workspace select unless TF_WORKSPACE is set as well
+	Workspace string
+	// RunID is read from TFC_RUN_ID, and is only set for runs in Terraform Cloud or Enterprise
+	RunID string
+}
+
+// ticketTemplateFuncs are the extra functions available to ticket templates
+var ticketTemplateFuncs = template.FuncMap{
+	"env": os.Getenv,
+}
+
+// parseTicketTemplate parses a provider ticket template, returning nil for an empty template
+func parseTicketTemplate(name string, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil
+	}
+	return template.New(name).Funcs(ticketTemplateFuncs).Option("missingkey=error").Parse(text)
+}
+
+// validateTicketTemplate is a schema ValidateFunc for ticket template arguments
+func validateTicketTemplate(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseTicketTemplate(key, val.(string)); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
+// ticketOptionsSchema adds the per-resource justification, priority, requester and auto-advance overrides to a resource schema
+func ticketOptionsSchema(s map[string]*schema.Schema, forceNew bool) map[string]*schema.Schema {
+	s["justification"] = &schema.Schema{
+		Type:        schema.TypeString,
+		ForceNew:    forceNew,
+		Optional:    true,
+		Description: "Business justification made available to the provider's ticket templates.",
+	}
+	s["priority"] = &schema.Schema{
+		Type:         schema.TypeString,
+		ForceNew:     forceNew,
+		Optional:     true,
+		ValidateFunc: validateTicketPriority,
+		Description:  "Ticket priority, overriding the provider's ticket_priority.",
+	}
+	s["requester"] = &schema.Schema{
+		Type:        schema.TypeString,
+		ForceNew:    forceNew,
+		Optional:    true,
+		Description: "User the ticket is opened on behalf of.",
+	}
+	s["auto_advance_steps"] = autoAdvanceStepsSchema(forceNew)
+	return s
+}
+
+// autoAdvanceStepsSchema is the schema for the names of ticket steps the provider completes itself
+func autoAdvanceStepsSchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		ForceNew:    forceNew,
+		Optional:    true,
+		Description: "Names of ticket steps, such as Approve or Verify, the provider completes and approves itself for pre-approved standard changes.",
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// ticketOptions combines the provider's templates and defaults with the overrides configured on a resource
+func (meta *providerMeta) ticketOptions(d *schema.ResourceData) ticketOptions {
+	opts := meta.TicketDefaults
+	opts.Warnings = &ticketWarnings{}
+	if v, ok := d.GetOk("justification"); ok {
+		opts.Justification = v.(string)
+	}
+	if v, ok := d.GetOk("priority"); ok {
+		opts.Priority = v.(string)
+	}
+	if v, ok := d.GetOk("requester"); ok {
+		opts.Requester = v.(string)
+	}
+	if v, ok := d.GetOk("auto_advance_steps"); ok {
+		opts.AutoAdvanceSteps = nil
+		for _, step := range v.([]interface{}) {
+			opts.AutoAdvanceSteps = append(opts.AutoAdvanceSteps, step.(string))
+		}
+	}
+	return opts
+}
+
+// apply renders the templates onto a ticket and sets its priority and requester
+func (opts ticketOptions) apply(ticket *SecureChangeTicket) error {
+	if opts.Priority != "" {
+		ticket.Priority = opts.Priority
+	}
+	if opts.Requester != "" {
+		ticket.Requester = opts.Requester
+	}
+
+	data := ticketTemplateData{
+		Subject:       ticket.Subject,
+		Justification: opts.Justification,
+		Priority:      ticket.Priority,
+		Requester:     ticket.Requester,
+		Workspace:     firstEnv("TF_WORKSPACE", "TFC_WORKSPACE_NAME"),
+		RunID:         firstEnv("TFC_RUN_ID"),
+	}
+
+	if opts.SubjectTemplate != nil {
+		var subject bytes.Buffer
+		if err := opts.SubjectTemplate.Execute(&subject, data); err != nil {
+			return err
+		}
+		ticket.Subject = strings.TrimSpace(subject.String())
+	}
+
+	comment := opts.Justification
+	if opts.CommentTemplate != nil {
+		var rendered bytes.Buffer
+		if err := opts.CommentTemplate.Execute(&rendered, data); err != nil {
+			return err
+		}
+		comment = strings.TrimSpace(rendered.String())
+	}
+	if comment != "" {
+		ticket.Comments = &SecureChangeComments{
+			Comment: []SecureChangeComment{{Content: comment}},
+		}
+	}
+	return nil
+}
+
+// firstEnv returns the first of the given environment variables that is set
+func firstEnv(keys ...string) string {
+	for _, key := range keys {
+		if v := os.Getenv(key); v != "" {
+			return v
+		}
+	}
+	return ""
+}
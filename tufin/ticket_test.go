@@ -0,0 +1,64 @@
+package tufin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTicketDate(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected time.Time
+		err      bool
+	}{
+		{value: "2020-12-17", expected: time.Date(2020, 12, 17, 0, 0, 0, 0, time.UTC)},
+		{value: "2020-12-17T15:04:05Z", expected: time.Date(2020, 12, 17, 15, 4, 5, 0, time.UTC)},
+		{value: "2020-12-17T15:04:05+01:00", expected: time.Date(2020, 12, 17, 14, 4, 5, 0, time.UTC)},
+		{value: "2020-12-17T15:04:05.123-0500", expected: time.Date(2020, 12, 17, 20, 4, 5, 123000000, time.UTC)},
+		{value: "2020-12-17T15:04:05-0500", expected: time.Date(2020, 12, 17, 20, 4, 5, 0, time.UTC)},
+		{value: "17/12/2020", err: true},
+		{value: "", err: true},
+	}
+
+	for _, c := range cases {
+		actual, err := parseTicketDate(c.value)
+		if c.err {
+			if err == nil {
+				t.Errorf("parseTicketDate(%q) succeeded, expected an error", c.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseTicketDate(%q) returned error: %s", c.value, err)
+			continue
+		}
+		if !actual.Equal(c.expected) {
+			t.Errorf("parseTicketDate(%q) = %s, expected %s", c.value, actual, c.expected)
+		}
+	}
+}
+
+func TestTicketDateEnd(t *testing.T) {
+	cases := []struct {
+		value    string
+		created  time.Time
+		included bool
+	}{
+		{value: "2020-12-17", created: time.Date(2020, 12, 17, 0, 0, 0, 0, time.UTC), included: true},
+		{value: "2020-12-17", created: time.Date(2020, 12, 17, 23, 59, 59, 0, time.UTC), included: true},
+		{value: "2020-12-17", created: time.Date(2020, 12, 18, 0, 0, 0, 0, time.UTC), included: false},
+		{value: "2020-12-17T12:00:00Z", created: time.Date(2020, 12, 17, 12, 0, 0, 0, time.UTC), included: true},
+		{value: "2020-12-17T12:00:00Z", created: time.Date(2020, 12, 17, 12, 0, 1, 0, time.UTC), included: false},
+	}
+
+	for _, c := range cases {
+		end, err := ticketDateEnd(c.value)
+		if err != nil {
+			t.Errorf("ticketDateEnd(%q) returned error: %s", c.value, err)
+			continue
+		}
+		if included := !c.created.After(end); included != c.included {
+			t.Errorf("ticket created %s included before %q = %t, expected %t", c.created, c.value, included, c.included)
+		}
+	}
+}
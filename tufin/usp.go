@@ -0,0 +1,304 @@
+package tufin
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// uspCSVHeader is the column layout SecureTrack uses to import and export Unified Security Policy matrices
+var uspCSVHeader = []string{"from domain", "from zone", "to domain", "to zone", "severity", "access type", "services/applications", "rule properties", "flows", "description"}
+
+// uspAccessTypes maps the access arguments onto the access types used in USP matrices
+var uspAccessTypes = map[string]string{
+	"allow_all":  "allow all",
+	"block_all":  "block all",
+	"allow_only": "allow only",
+	"block_only": "block only",
+}
+
+// uspCell represents a single zone to zone cell of a Unified Security Policy matrix
+type uspCell struct {
+	FromZone    string
+	ToZone      string
+	Access      string
+	Services    []string
+	Severity    string
+	Description string
+}
+
+// marshalUSP renders matrix cells in SecureTrack's USP CSV format
+func marshalUSP(cells []uspCell) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(uspCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, cell := range cells {
+		record := []string{"", cell.FromZone, "", cell.ToZone, cell.Severity, uspAccessTypes[cell.Access], strings.Join(cell.Services, ";"), "", "", cell.Description}
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// unmarshalUSP parses matrix cells from SecureTrack's USP CSV format
+func unmarshalUSP(data []byte) ([]uspCell, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	cells := []uspCell{}
+	for i, record := range records {
+		if i == 0 || len(record) < len(uspCSVHeader) {
+			continue
+		}
+		access := ""
+		for key, value := range uspAccessTypes {
+			if strings.EqualFold(value, record[5]) {
+				access = key
+			}
+		}
+		services := []string{}
+		for _, service := range strings.Split(record[6], ";") {
+			if service = strings.TrimSpace(service); service != "" {
+				services = append(services, service)
+			}
+		}
+		cells = append(cells, uspCell{
+			FromZone:    record[1],
+			ToZone:      record[3],
+			Access:      access,
+			Services:    services,
+			Severity:    strings.ToLower(record[4]),
+			Description: record[9],
+		})
+	}
+	return cells, nil
+}
+
+// importSecurityPolicy imports a Unified Security Policy matrix from CSV and returns its ID
+func importSecurityPolicy(c *tufinclient.SecureTrackClient, name string, data []byte) (string, error) {
+	response, err := c.R().
+		SetFileReader("file", name+".csv", bytes.NewReader(data)).
+		SetFormData(map[string]string{"security_policy_name": name}).
+		Post("/security_policies")
+	if err != nil {
+		return "", err
+	}
+	return createdID(response)
+}
+
+// exportSecurityPolicy exports a Unified Security Policy matrix as CSV, returning nil when it does not exist
+func exportSecurityPolicy(c *tufinclient.SecureTrackClient, id string) ([]byte, error) {
+	response, err := c.R().
+		SetHeader("Accept", "text/csv").
+		Get("/security_policies/" + id + "/export")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return response.Body(), nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// deleteSecurityPolicy deletes a Unified Security Policy matrix, treating one that is already gone as deleted
+func deleteSecurityPolicy(c *tufinclient.SecureTrackClient, id string) error {
+	response, err := c.R().
+		Delete("/security_policies/" + id)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() == 404 {
+		return nil
+	}
+	return emptyResult(response)
+}
+
+// getDeviceViolations lists the rules on a device that violate a Unified Security Policy
+func getDeviceViolations(c *tufinclient.SecureTrackClient, deviceID int64) ([]SecureTrackViolatingRule, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackDeviceViolationsResult{}).
+		SetQueryParam("type", "SECURITY_POLICY").
+		SetHeader("Accept", "application/json").
+		Get(fmt.Sprintf("/violating_rules/%d/device_violations", deviceID))
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		return response.Result().(*SecureTrackDeviceViolationsResult).SecurityPolicyDeviceViolations.ViolatingRules.ViolatingRule, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getAccessRequestViolations returns the Unified Security Policy violations the given access would introduce
+func getAccessRequestViolations(c *tufinclient.SecureTrackClient, request SecureChangeAccessRequest) ([]SecureChangeSecurityPolicyViolation, error) {
+	body := SecureTrackAccessRequestViolationsRequest{}
+	body.AccessRequests.AccessRequest = []SecureChangeAccessRequest{request}
+
+	response, err := c.R().
+		SetBody(body).
+		SetResult(&SecureTrackAccessRequestViolationsResult{}).
+		SetHeader("Accept", "application/json").
+		Post("/violations/access_requests/sync")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		violations := []SecureChangeSecurityPolicyViolation{}
+		for _, v := range response.Result().(*SecureTrackAccessRequestViolationsResult).AccessRequestViolations.AccessRequestViolation {
+			violations = append(violations, v.Violations.Violation...)
+		}
+		return violations, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getUSPException retrieves a USP exception by ID, returning nil when it does not exist
+func getUSPException(c *tufinclient.SecureTrackClient, id string) (*SecureTrackUSPException, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackUSPExceptionRequest{}).
+		SetHeader("Accept", "application/json").
+		Get("/security_policies/exceptions/" + id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return &response.Result().(*SecureTrackUSPExceptionRequest).Exception, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// createUSPException creates a USP exception and returns its ID
+func createUSPException(c *tufinclient.SecureTrackClient, exception SecureTrackUSPException) (string, error) {
+	response, err := c.R().
+		SetBody(SecureTrackUSPExceptionRequest{Exception: exception}).
+		Post("/security_policies/exceptions")
+	if err != nil {
+		return "", err
+	}
+	return createdID(response)
+}
+
+// deleteUSPException deletes a USP exception, treating one that is already gone as deleted
+func deleteUSPException(c *tufinclient.SecureTrackClient, id string) error {
+	response, err := c.R().
+		Delete("/security_policies/exceptions/" + id)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() == 404 {
+		return nil
+	}
+	return emptyResult(response)
+}
+
+// exemptedNetworks converts address arguments into the network items of exempted traffic
+func exemptedNetworks(values []interface{}) (SecureTrackExemptedNetworks, error) {
+	networks := SecureTrackExemptedNetworks{}
+	for _, v := range values {
+		addr, err := parseNetworkAddress(v.(string))
+		if err != nil {
+			return networks, err
+		}
+		item := SecureTrackExemptedNetworkItem{XsiType: "subnet", IP: addr.IP, Netmask: addr.Mask}
+		if addr.LastIP != "" {
+			item = SecureTrackExemptedNetworkItem{XsiType: "range", FirstIP: addr.IP, LastIP: addr.LastIP}
+		}
+		networks.NetworkItems.NetworkItem = append(networks.NetworkItems.NetworkItem, item)
+	}
+	return networks, nil
+}
+
+// exemptedServices converts service arguments such as "tcp 443" into the service items of exempted traffic
+func exemptedServices(values []interface{}) (SecureTrackExemptedServices, error) {
+	services := SecureTrackExemptedServices{}
+	for _, v := range values {
+		service, err := topologyServiceDefinition(v.(string))
+		if err != nil {
+			return services, err
+		}
+		services.ServiceItems.ServiceItem = append(services.ServiceItems.ServiceItem, SecureTrackExemptedServiceItem{
+			XsiType:  "custom",
+			Protocol: service.Protocol,
+			Port:     service.Port(),
+		})
+	}
+	return services, nil
+}
+
+// flattenExemptedNetworks converts the network items of exempted traffic back into address arguments
+func flattenExemptedNetworks(networks SecureTrackExemptedNetworks) []interface{} {
+	values := make([]interface{}, 0, len(networks.NetworkItems.NetworkItem))
+	for _, item := range networks.NetworkItems.NetworkItem {
+		value := rangeAddress(item.FirstIP, item.LastIP)
+		if value == "" {
+			// IPv6 subnets carry a prefix length rather than a netmask
+			if _, err := strconv.Atoi(item.Netmask); err == nil {
+				value = item.IP + "/" + item.Netmask
+			} else {
+				value = objectAddress(&tufinclient.SecureTrackNetworkObject{IP: item.IP, Netmask: item.Netmask})
+			}
+		}
+		if addr, err := parseNetworkAddress(value); err == nil {
+			value = addr.Value
+		}
+		values = append(values, value)
+	}
+	return values
+}
+
+// flattenExemptedServices converts the service items of exempted traffic back into service arguments
+func flattenExemptedServices(services SecureTrackExemptedServices) []interface{} {
+	values := make([]interface{}, 0, len(services.ServiceItems.ServiceItem))
+	for _, item := range services.ServiceItems.ServiceItem {
+		values = append(values, strings.ToLower(item.Protocol)+" "+item.Port)
+	}
+	return values
+}
+
+// suppressEquivalentService is a DiffSuppressFunc ignoring differences in how the same service is written, such as
+// "TCP 443" and "tcp 443"
+func suppressEquivalentService(k, old, new string, d *schema.ResourceData) bool {
+	oldService, err := topologyServiceDefinition(old)
+	if err != nil {
+		return false
+	}
+	newService, err := topologyServiceDefinition(new)
+	if err != nil {
+		return false
+	}
+	return oldService.ObjectDetails() == newService.ObjectDetails()
+}
@@ -0,0 +1,96 @@
+package tufin
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMarshalUSP(t *testing.T) {
+	cells := []uspCell{
+		{FromZone: "Internal", ToZone: "DMZ", Access: "allow_only", Services: []string{"tcp 443", "tcp 22"}, Severity: "high", Description: "Web and SSH only"},
+		{FromZone: "DMZ", ToZone: "Internal", Access: "block_all", Services: []string{}, Severity: "critical", Description: "No access, ever"},
+	}
+	expected := strings.Join([]string{
+		"from domain,from zone,to domain,to zone,severity,access type,services/applications,rule properties,flows,description",
+		",Internal,,DMZ,high,allow only,tcp 443;tcp 22,,,Web and SSH only",
+		",DMZ,,Internal,critical,block all,,,,\"No access, ever\"",
+		"",
+	}, "\n")
+
+	data, err := marshalUSP(cells)
+	if err != nil {
+		t.Fatalf("marshalUSP returned error: %s", err)
+	}
+	if string(data) != expected {
+		t.Errorf("marshalUSP = %q, expected %q", data, expected)
+	}
+}
+
+func TestUnmarshalUSP(t *testing.T) {
+	cases := []struct {
+		data     string
+		expected []uspCell
+		err      bool
+	}{
+		{
+			data: "from domain,from zone,to domain,to zone,severity,access type,services/applications,rule properties,flows,description\n" +
+				",Internal,,DMZ,HIGH,Allow Only, tcp 443 ; tcp 22 ,,,Web and SSH only\n" +
+				"Default,DMZ,Default,Internal,critical,block all,,,,\"No access, ever\"\n",
+			expected: []uspCell{
+				{FromZone: "Internal", ToZone: "DMZ", Access: "allow_only", Services: []string{"tcp 443", "tcp 22"}, Severity: "high", Description: "Web and SSH only"},
+				{FromZone: "DMZ", ToZone: "Internal", Access: "block_all", Services: []string{}, Severity: "critical", Description: "No access, ever"},
+			},
+		},
+		{
+			data:     "from domain,from zone,to domain,to zone,severity,access type,services/applications,rule properties,flows,description\n",
+			expected: []uspCell{},
+		},
+		{
+			data: "from domain,from zone,to domain,to zone,severity,access type,services/applications,rule properties,flows,description\n" +
+				",Internal,,DMZ,high,unknown,,,,\n",
+			expected: []uspCell{
+				{FromZone: "Internal", ToZone: "DMZ", Access: "", Services: []string{}, Severity: "high"},
+			},
+		},
+		{
+			data: "from domain,from zone\n\"unterminated,quote\n",
+			err:  true,
+		},
+	}
+
+	for _, c := range cases {
+		cells, err := unmarshalUSP([]byte(c.data))
+		if c.err {
+			if err == nil {
+				t.Errorf("unmarshalUSP(%q) succeeded, expected an error", c.data)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unmarshalUSP(%q) returned error: %s", c.data, err)
+			continue
+		}
+		if !reflect.DeepEqual(cells, c.expected) {
+			t.Errorf("unmarshalUSP(%q) = %+v, expected %+v", c.data, cells, c.expected)
+		}
+	}
+}
+
+func TestUSPRoundTrip(t *testing.T) {
+	cells := []uspCell{
+		{FromZone: "Internal", ToZone: "Internet", Access: "block_only", Services: []string{"udp 53"}, Severity: "medium", Description: "No external DNS"},
+		{FromZone: "Internet", ToZone: "Internal", Access: "allow_all", Services: []string{}, Severity: "low"},
+	}
+	data, err := marshalUSP(cells)
+	if err != nil {
+		t.Fatalf("marshalUSP returned error: %s", err)
+	}
+	parsed, err := unmarshalUSP(data)
+	if err != nil {
+		t.Fatalf("unmarshalUSP returned error: %s", err)
+	}
+	if !reflect.DeepEqual(parsed, cells) {
+		t.Errorf("round trip = %+v, expected %+v", parsed, cells)
+	}
+}
@@ -0,0 +1,219 @@
+package tufin
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+
+	"github.com/jgrancell/go-tufinclient/tufinclient"
+)
+
+// apiResponse is the part of a client response the create and update helpers need
+type apiResponse interface {
+	StatusCode() int
+	String() string
+	Header() http.Header
+}
+
+// getZones lists every SecureTrack zone
+func getZones(c *tufinclient.SecureTrackClient) ([]SecureTrackZone, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackZonesResult{}).
+		SetHeader("Accept", "application/json").
+		Get("/zones")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 200:
+		return response.Result().(*SecureTrackZonesResult).Zones.Zone, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getZone retrieves a SecureTrack zone by ID, returning nil when it does not exist
+func getZone(c *tufinclient.SecureTrackClient, id string) (*SecureTrackZone, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackZoneRequest{}).
+		SetHeader("Accept", "application/json").
+		Get("/zones/" + id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return &response.Result().(*SecureTrackZoneRequest).Zone, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// createZone creates a SecureTrack zone and returns its ID
+func createZone(c *tufinclient.SecureTrackClient, zone SecureTrackZone) (string, error) {
+	response, err := c.R().
+		SetBody(SecureTrackZoneRequest{Zone: zone}).
+		Post("/zones")
+	if err != nil {
+		return "", err
+	}
+	return createdID(response)
+}
+
+// updateZone replaces the name and comment of a SecureTrack zone. The API replaces the whole zone, so its shared and
+// internet settings are read first and sent back unchanged.
+func updateZone(c *tufinclient.SecureTrackClient, id string, name string, comment string) error {
+	zone, err := getZone(c, id)
+	if err != nil {
+		return err
+	}
+	if zone == nil {
+		return fmt.Errorf("Zone %s no longer exists in SecureTrack", id)
+	}
+	zone.Name, zone.Comment = name, comment
+
+	response, err := c.R().
+		SetBody(SecureTrackZoneRequest{Zone: *zone}).
+		Put("/zones/" + id)
+	if err != nil {
+		return err
+	}
+	return emptyResult(response)
+}
+
+// deleteZone deletes a SecureTrack zone, treating one that is already gone as deleted
+func deleteZone(c *tufinclient.SecureTrackClient, id string) error {
+	response, err := c.R().
+		Delete("/zones/" + id)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() == 404 {
+		return nil
+	}
+	return emptyResult(response)
+}
+
+// getZoneEntries lists the entries of a SecureTrack zone
+func getZoneEntries(c *tufinclient.SecureTrackClient, zoneID string) ([]SecureTrackZoneEntry, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackZoneEntriesResult{}).
+		SetHeader("Accept", "application/json").
+		Get("/zones/" + zoneID + "/entries")
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return response.Result().(*SecureTrackZoneEntriesResult).ZoneEntries.ZoneEntry, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// getZoneEntry retrieves a single zone entry, returning nil when it or its zone does not exist
+func getZoneEntry(c *tufinclient.SecureTrackClient, zoneID string, id string) (*SecureTrackZoneEntry, error) {
+	response, err := c.R().
+		SetResult(&SecureTrackZoneEntryRequest{}).
+		SetHeader("Accept", "application/json").
+		Get("/zones/" + zoneID + "/entries/" + id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode() {
+	case 401:
+		return nil, fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	case 404:
+		return nil, nil
+	case 200:
+		return &response.Result().(*SecureTrackZoneEntryRequest).ZoneEntry, nil
+	default:
+		return nil, fmt.Errorf("%s", response.String())
+	}
+}
+
+// createZoneEntry adds an entry to a SecureTrack zone and returns its ID
+func createZoneEntry(c *tufinclient.SecureTrackClient, zoneID string, entry SecureTrackZoneEntry) (string, error) {
+	response, err := c.R().
+		SetBody(SecureTrackZoneEntryRequest{ZoneEntry: entry}).
+		Post("/zones/" + zoneID + "/entries")
+	if err != nil {
+		return "", err
+	}
+	return createdID(response)
+}
+
+// updateZoneEntry replaces a zone entry
+func updateZoneEntry(c *tufinclient.SecureTrackClient, zoneID string, id string, entry SecureTrackZoneEntry) error {
+	response, err := c.R().
+		SetBody(SecureTrackZoneEntryRequest{ZoneEntry: entry}).
+		Put("/zones/" + zoneID + "/entries/" + id)
+	if err != nil {
+		return err
+	}
+	return emptyResult(response)
+}
+
+// deleteZoneEntry removes an entry from a SecureTrack zone, treating one that is already gone as deleted
+func deleteZoneEntry(c *tufinclient.SecureTrackClient, zoneID string, id string) error {
+	response, err := c.R().
+		Delete("/zones/" + zoneID + "/entries/" + id)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode() == 404 {
+		return nil
+	}
+	return emptyResult(response)
+}
+
+// zoneEntryAddress renders a zone entry in the notation parseNetworkAddress accepts
+func zoneEntryAddress(entry *SecureTrackZoneEntry) string {
+	if entry.Netmask == "" && entry.Prefix != 0 {
+		return entry.IP + "/" + strconv.Itoa(entry.Prefix)
+	}
+	return objectAddress(&tufinclient.SecureTrackNetworkObject{IP: entry.IP, Netmask: entry.Netmask})
+}
+
+// createdID reads the ID of a newly created SecureTrack item from the Location header of a 201 response
+func createdID(response apiResponse) (string, error) {
+	switch response.StatusCode() {
+	case 201:
+		id := path.Base(response.Header().Get("Location"))
+		if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+			return "", fmt.Errorf("Could not read ID from SecureTrack response location %q", response.Header().Get("Location"))
+		}
+		return id, nil
+	case 401:
+		return "", fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return "", fmt.Errorf("%s", response.String())
+	}
+}
+
+// emptyResult checks the status of a SecureTrack request that returns no body
+func emptyResult(response apiResponse) error {
+	switch response.StatusCode() {
+	case 200, 204:
+		return nil
+	case 401:
+		return fmt.Errorf("Unauthorized request. Tufin API returned: %s", response.String())
+	default:
+		return fmt.Errorf("%s", response.String())
+	}
+}